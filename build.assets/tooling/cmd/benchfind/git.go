@@ -0,0 +1,70 @@
+/*
+ * Teleport
+ * Copyright (C) 2026  Gravitational, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gravitational/trace"
+)
+
+// changedDirPatterns returns package patterns for the directories containing
+// files changed relative to the given git ref. Directories that no longer
+// exist or no longer contain Go files are skipped.
+func changedDirPatterns(ref string) ([]string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, trace.BadParameter("git is not available: %v", err)
+	}
+	var stdout, stderr bytes.Buffer
+	// --relative makes the reported paths relative to the current working
+	// directory, matching the directory package loading runs from.
+	cmd := exec.Command("git", "diff", "--name-only", "--relative", ref)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, trace.BadParameter("git diff --name-only %v failed: %v", ref, strings.TrimSpace(stderr.String()))
+	}
+
+	var patterns []string
+	for _, file := range strings.Split(stdout.String(), "\n") {
+		if file = strings.TrimSpace(file); file == "" {
+			continue
+		}
+		dir := filepath.Dir(file)
+		pattern := "./" + filepath.ToSlash(dir)
+		if slices.Contains(patterns, pattern) {
+			continue
+		}
+		if !dirHasGoFiles(dir) {
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// dirHasGoFiles reports whether dir exists and contains at least one Go file.
+func dirHasGoFiles(dir string) bool {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	return err == nil && len(matches) > 0
+}
@@ -0,0 +1,277 @@
+/*
+ * Teleport
+ * Copyright (C) 2026  Gravitational, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Command benchfind lists the packages that define benchmark functions.
+//
+//	Usage: benchfind [flags] [packages...]
+//
+// Benchfind loads the given package patterns (defaulting to ./...) and
+// prints the import path of every package that declares at least one
+// top-level `func BenchmarkXxx(*testing.B)`. It is used by CI to decide
+// which packages need to be included in benchmark runs.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/gravitational/trace"
+
+	"github.com/gravitational/teleport/build.assets/tooling/lib/benchfind"
+)
+
+const (
+	formatText   = "text"
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+)
+
+var (
+	format        = flag.String("format", formatText, "Output format: text, json or ndjson (one package object per line, streamed)")
+	exclude       = flag.String("exclude", "", "Comma-separated list of import path prefixes or glob patterns to exclude, applied on top of any .benchfindignore file")
+	include       = flag.String("include", "", "Comma-separated list of import path prefixes or glob patterns to keep; applied before --exclude")
+	kind          = flag.String("kind", benchfind.KindBenchmark, "Kind of test functions to discover: benchmark, fuzz or both")
+	jobs          = flag.Int("jobs", runtime.GOMAXPROCS(0), "Number of packages to scan concurrently")
+	patternsFrom  = flag.String("patterns-from", "", "File with newline-separated package patterns to merge with positional arguments, - for stdin")
+	relative      = flag.Bool("relative", false, "In text mode, print directory paths relative to the working directory instead of import paths")
+	count         = flag.Bool("count", false, "In text mode, print package\\tN lines counting the discovered functions per package")
+	failIfEmpty   = flag.Bool("fail-if-empty", false, "Exit non-zero when no matching functions are found")
+	changedSince  = flag.String("changed-since", "", "Only scan directories with changes relative to the given git ref")
+	bestEffort    = flag.Bool("best-effort", false, "Downgrade package load errors to stderr warnings instead of failing the run")
+	warnMalformed = flag.Bool("warn-malformed", false, "Warn on stderr about Benchmark-named functions the test runner would not pick up")
+	tagSets       stringListFlag
+	requiredTags  = flag.Bool("required-tags", false, "Report the build tags each package's //go:build constraints require, in the json and ndjson formats")
+	splitExternal = flag.Bool("split-external", false, "Report the internal and external test variants of a package separately, with the discovered package path and an external flag")
+	requireBLoop  = flag.Bool("require-bloop", false, "Audit mode: list benchmarks still using the legacy b.N loop style and exit non-zero if any are found")
+)
+
+func main() {
+	flag.Var(&tagSets, "tags", "Build tag set to evaluate, in go build -tags form; may be repeated to union discovery across several tag sets")
+	flag.Parse()
+	if err := run(flag.Args(), os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(patterns []string, stdin io.Reader, out io.Writer) error {
+	if *changedSince != "" {
+		changed, err := changedDirPatterns(*changedSince)
+		if err != nil {
+			return trace.Wrap(err)
+		}
+		if len(changed) == 0 {
+			// Nothing changed, so there is nothing to scan.
+			return nil
+		}
+		// The changed directories replace the requested patterns; includes
+		// and excludes still filter the loaded packages as usual.
+		patterns = changed
+	}
+	if *patternsFrom != "" {
+		filePatterns, err := readPatterns(*patternsFrom, stdin)
+		if err != nil {
+			return trace.Wrap(err)
+		}
+		patterns = append(patterns, filePatterns...)
+	}
+	cfg := benchfind.Config{
+		Patterns:            patterns,
+		Includes:            splitCommaList(*include),
+		Excludes:            splitCommaList(*exclude),
+		Kind:                *kind,
+		Jobs:                *jobs,
+		TagSets:             tagSets,
+		CollectRequiredTags: *requiredTags,
+		SplitExternal:       *splitExternal,
+	}
+	if *bestEffort {
+		cfg.OnLoadError = func(err error) {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		}
+	}
+	if *warnMalformed {
+		cfg.OnMalformed = func(pkgPath string, fn benchfind.TestFunc) {
+			fmt.Fprintf(os.Stderr, "warning: %s: %s is named like a benchmark but will not run as one\n", fn.Location, fn.Name)
+		}
+	}
+	if *format == formatNDJSON && !*requireBLoop {
+		return trace.Wrap(runNDJSON(cfg, patterns, out))
+	}
+	pkgs, err := benchfind.Find(cfg)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	if *failIfEmpty && len(pkgs) == 0 {
+		displayPatterns := patterns
+		if len(displayPatterns) == 0 {
+			displayPatterns = []string{"./..."}
+		}
+		return trace.BadParameter("no %v functions found in packages matching %v", *kind, displayPatterns)
+	}
+
+	if *requireBLoop {
+		var offenders int
+		for _, pkg := range pkgs {
+			for _, benchmark := range pkg.Benchmarks {
+				if benchmark.LegacyLoop {
+					fmt.Fprintf(out, "%s:%s\n", pkg.Path, benchmark.Name)
+					offenders++
+				}
+			}
+		}
+		if offenders > 0 {
+			return trace.BadParameter("%d benchmarks reference b.N without calling b.Loop", offenders)
+		}
+		return nil
+	}
+
+	switch *format {
+	case formatText:
+		for _, pkg := range pkgs {
+			name := pkg.Path
+			// With --split-external the discovered path keeps the _test
+			// suffix of external test packages, telling the variants apart.
+			if *splitExternal && pkg.DiscoveredPath != "" {
+				name = pkg.DiscoveredPath
+			}
+			if *relative {
+				name = relativeDir(pkg)
+			}
+			if *count {
+				// Only top-level declarations are discovered, so subtests
+				// spawned via b.Run never inflate the count.
+				fmt.Fprintf(out, "%s\t%d\n", name, len(pkg.Benchmarks)+len(pkg.Fuzz))
+				continue
+			}
+			fmt.Fprintln(out, name)
+		}
+	case formatJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(pkgs); err != nil {
+			return trace.Wrap(err, "encoding packages as JSON")
+		}
+	default:
+		return trace.BadParameter("unsupported output format %q, expected %q, %q or %q", *format, formatText, formatJSON, formatNDJSON)
+	}
+	return nil
+}
+
+// runNDJSON streams each discovered package to out as a single JSON line the
+// moment its scan completes, flushing per line so consumers on very large
+// trees see results without waiting for the full run, and without the whole
+// result set being held in memory.
+func runNDJSON(cfg benchfind.Config, patterns []string, out io.Writer) error {
+	w := bufio.NewWriter(out)
+	enc := json.NewEncoder(w)
+	var emitted int
+	err := benchfind.FindEach(cfg, func(pkg benchfind.Package) error {
+		// Encode appends the newline, making each package its own line.
+		if err := enc.Encode(pkg); err != nil {
+			return trace.Wrap(err, "encoding package as JSON")
+		}
+		emitted++
+		return trace.Wrap(w.Flush())
+	})
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	if *failIfEmpty && emitted == 0 {
+		displayPatterns := patterns
+		if len(displayPatterns) == 0 {
+			displayPatterns = []string{"./..."}
+		}
+		return trace.BadParameter("no %v functions found in packages matching %v", *kind, displayPatterns)
+	}
+	return nil
+}
+
+// relativeDir returns the package directory relative to the working
+// directory, in ./dir form, falling back to the import path when the
+// directory is unknown or outside the working directory.
+func relativeDir(pkg benchfind.Package) string {
+	if pkg.Dir == "" {
+		return pkg.Path
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return pkg.Path
+	}
+	rel, err := filepath.Rel(wd, pkg.Dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return pkg.Path
+	}
+	return "./" + filepath.ToSlash(rel)
+}
+
+// readPatterns reads newline-separated package patterns from the named file,
+// or from stdin when the name is -. Blank lines and # comments are skipped.
+func readPatterns(name string, stdin io.Reader) ([]string, error) {
+	r := stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, trace.Wrap(err, "opening patterns file")
+		}
+		defer f.Close()
+		r = f
+	}
+	var patterns []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, trace.Wrap(err, "reading patterns from %v", name)
+	}
+	return patterns, nil
+}
+
+// stringListFlag collects the values of a repeatable string flag.
+type stringListFlag []string
+
+func (f *stringListFlag) String() string { return strings.Join(*f, " ") }
+
+func (f *stringListFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// splitCommaList splits a comma-separated flag value, dropping empty entries
+// so that an unset flag yields a nil slice.
+func splitCommaList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,711 @@
+/*
+ * Teleport
+ * Copyright (C) 2026  Gravitational, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package benchfind discovers the benchmark functions and fuzz targets
+// declared in a tree of Go packages. It backs the benchfind command but can
+// also be imported directly by other tooling.
+package benchfind
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/token"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/gravitational/trace"
+	"golang.org/x/tools/go/packages"
+)
+
+// Kinds of test functions that Find can discover.
+const (
+	// KindBenchmark discovers benchmark functions only.
+	KindBenchmark = "benchmark"
+	// KindFuzz discovers fuzz targets only.
+	KindFuzz = "fuzz"
+	// KindBoth discovers both benchmark functions and fuzz targets.
+	KindBoth = "both"
+)
+
+// Config configures a benchmark discovery run.
+type Config struct {
+	// Dir is the directory package loading runs from. Empty means the
+	// current working directory.
+	Dir string
+	// Patterns are the package patterns to load, defaulting to ./...
+	Patterns []string
+	// Includes is a list of import path prefixes or glob patterns acting as
+	// an allowlist: when non-empty, only matching packages are considered.
+	// Includes are applied before Excludes.
+	Includes []string
+	// Excludes is a list of import path prefixes or glob patterns to skip.
+	// Patterns from a .benchfindignore file in Dir, when one exists, are
+	// applied in addition to these.
+	Excludes []string
+	// Kind selects the kind of test functions to discover, one of
+	// [KindBenchmark], [KindFuzz] or [KindBoth]. Empty means [KindBenchmark].
+	Kind string
+	// Jobs bounds the number of packages scanned concurrently. Zero or
+	// negative means GOMAXPROCS.
+	Jobs int
+	// TagSets lists build tag sets to evaluate, each in the comma-separated
+	// form accepted by go build -tags. Discovery runs once per tag set and
+	// the results are unioned. Empty means a single run with no extra tags.
+	TagSets []string
+	// OnLoadError, when set, downgrades package load errors to calls of the
+	// callback: packages that failed to load are skipped and discovery
+	// continues with the ones that loaded cleanly. When nil, any load error
+	// fails the whole run.
+	OnLoadError func(err error)
+	// OnMalformed, when set, is called for every top-level function whose
+	// name starts with Benchmark but that the go test runner would not pick
+	// up as a benchmark, e.g. because of a wrong signature or a lowercase
+	// character after the prefix. Calls happen sequentially.
+	OnMalformed func(pkgPath string, fn TestFunc)
+	// CollectRequiredTags inspects the //go:build constraints of the files
+	// declaring discovered functions and populates [Package.RequiredTags],
+	// so runners can work out the -tags value a package needs.
+	CollectRequiredTags bool
+	// SplitExternal reports the internal and external test variants of a
+	// package as separate entries instead of merging them, populating
+	// [Package.DiscoveredPath] and [Package.External] on each. The
+	// distinction matters to tooling that cares whether a benchmark can
+	// reach unexported symbols.
+	SplitExternal bool
+}
+
+// Package describes a package that declares at least one benchmark or fuzz
+// target matching the configured kind.
+type Package struct {
+	// Path is the package import path.
+	Path string `json:"path"`
+	// Dir is the directory containing the package's Go files. It can be
+	// empty when the package directory could not be determined.
+	Dir string `json:"dir,omitempty"`
+	// TagSets lists the configured build tag sets under which the package
+	// surfaced. It is only populated when [Config.TagSets] is set.
+	TagSets []string `json:"tag_sets,omitempty"`
+	// Benchmarks are the top-level benchmark functions declared in the
+	// package, including its test variants.
+	Benchmarks []TestFunc `json:"benchmarks,omitempty"`
+	// Fuzz are the top-level fuzz targets declared in the package,
+	// including its test variants.
+	Fuzz []TestFunc `json:"fuzz,omitempty"`
+	// RequiredTags lists the build tags referenced positively by the
+	// //go:build constraints of the files declaring the discovered
+	// functions. It is only populated when [Config.CollectRequiredTags] is
+	// set, and stays empty for packages whose files carry no constraints.
+	// Negated tags and file-name based constraints are not reported.
+	RequiredTags []string `json:"required_tags,omitempty"`
+	// DiscoveredPath is the import path the package variant was loaded
+	// under, before the _test suffix of external test packages is trimmed
+	// off into Path. It is only populated when [Config.SplitExternal] is
+	// set.
+	DiscoveredPath string `json:"discovered_path,omitempty"`
+	// External is set on entries whose functions were declared in an
+	// external xxx_test package rather than in in-package _test.go files.
+	// It is only meaningful when [Config.SplitExternal] is set.
+	External bool `json:"external,omitempty"`
+}
+
+// TestFunc describes a single discovered benchmark function or fuzz target.
+type TestFunc struct {
+	// Name is the function name.
+	Name string `json:"name"`
+	// Location is the file:line position of the function declaration.
+	Location string `json:"location,omitempty"`
+	// LegacyLoop is set on benchmarks that reference b.N without ever
+	// calling b.Loop, i.e. ones still using the pre-Go 1.24 loop style.
+	LegacyLoop bool `json:"legacy_loop,omitempty"`
+	// Generic is set on declarations carrying type parameters. The test
+	// runner ignores those, so a generic benchmark needs a non-generic
+	// wrapper before it can run.
+	Generic bool `json:"generic,omitempty"`
+}
+
+// Find loads the packages matching cfg.Patterns and returns the ones that
+// declare at least one top-level benchmark function.
+func Find(cfg Config) ([]Package, error) {
+	var result []Package
+	// seen maps an import path to its index in result so that the same
+	// package surfacing under several tag sets collapses into a single
+	// entry.
+	seen := make(map[string]int)
+	err := FindEach(cfg, func(pkg Package) error {
+		// With SplitExternal the internal and external variants share an
+		// import path, so they must be kept apart by the discovered path.
+		key := pkg.Path
+		if cfg.SplitExternal {
+			key = pkg.DiscoveredPath
+		}
+		j, ok := seen[key]
+		if !ok {
+			j = len(result)
+			seen[key] = j
+			result = append(result, Package{
+				Path:           pkg.Path,
+				Dir:            pkg.Dir,
+				DiscoveredPath: pkg.DiscoveredPath,
+				External:       pkg.External,
+			})
+		}
+		result[j].Benchmarks = appendNewFuncs(result[j].Benchmarks, pkg.Benchmarks)
+		result[j].Fuzz = appendNewFuncs(result[j].Fuzz, pkg.Fuzz)
+		if result[j].Dir == "" {
+			result[j].Dir = pkg.Dir
+		}
+		for _, tags := range pkg.TagSets {
+			if !slices.Contains(result[j].TagSets, tags) {
+				result[j].TagSets = append(result[j].TagSets, tags)
+			}
+		}
+		for _, tag := range pkg.RequiredTags {
+			if !slices.Contains(result[j].RequiredTags, tag) {
+				result[j].RequiredTags = append(result[j].RequiredTags, tag)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+	// Functions within a package are sorted by name so that merging the
+	// same package across tag sets yields deterministic output.
+	byName := func(a, b TestFunc) int { return strings.Compare(a.Name, b.Name) }
+	for i := range result {
+		slices.SortFunc(result[i].Benchmarks, byName)
+		slices.SortFunc(result[i].Fuzz, byName)
+		slices.Sort(result[i].RequiredTags)
+	}
+	// Packages are sorted by import path rather than left in traversal
+	// order, so two runs over the same tree always diff cleanly. The
+	// discovered path breaks ties between split test variants, putting the
+	// internal variant first.
+	slices.SortFunc(result, func(a, b Package) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.DiscoveredPath, b.DiscoveredPath)
+	})
+	return result, nil
+}
+
+// FindEach discovers packages like [Find] but hands each one to emit as soon
+// as its scan completes, without accumulating the full result slice, so
+// consumers can stream output on very large trees. Packages arrive in
+// traversal order rather than [Find]'s sorted order, and with several tag
+// sets configured the same package is emitted once per tag set under which
+// it surfaces, carrying just that set in TagSets; consumers needing the
+// merged, sorted view should use [Find]. An error returned by emit stops the
+// run and is returned as is.
+func FindEach(cfg Config, emit func(Package) error) error {
+	kind := cfg.Kind
+	if kind == "" {
+		kind = KindBenchmark
+	}
+	switch kind {
+	case KindBenchmark, KindFuzz, KindBoth:
+	default:
+		return trace.BadParameter("unsupported kind %q, expected %q, %q or %q", kind, KindBenchmark, KindFuzz, KindBoth)
+	}
+	patterns := cfg.Patterns
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	tagSets := cfg.TagSets
+	if len(tagSets) == 0 {
+		tagSets = []string{""}
+	}
+	ignored, err := readIgnoreFile(cfg.Dir)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	// The ignore file extends rather than replaces the configured excludes,
+	// so callers can still pass ad-hoc patterns on top of it.
+	cfg.Excludes = append(ignored, cfg.Excludes...)
+
+	byName := func(a, b TestFunc) int { return strings.Compare(a.Name, b.Name) }
+	for _, tags := range tagSets {
+		scanned, err := scanPackages(cfg, kind, patterns, tags)
+		if err != nil {
+			return trace.Wrap(err)
+		}
+		// The test variants of a package scan as separate entries sharing
+		// an import path, so merge them before emitting: each package is
+		// emitted at most once per tag set.
+		var merged []Package
+		seen := make(map[string]int)
+		for _, s := range scanned {
+			if cfg.OnMalformed != nil {
+				for _, fn := range s.malformed {
+					cfg.OnMalformed(s.path, fn)
+				}
+			}
+			if len(s.benchmarks) == 0 && len(s.fuzz) == 0 {
+				continue
+			}
+			key := s.path
+			if cfg.SplitExternal {
+				key = s.discoveredPath
+			}
+			j, ok := seen[key]
+			if !ok {
+				j = len(merged)
+				seen[key] = j
+				merged = append(merged, Package{Path: s.path, Dir: s.dir})
+				if cfg.SplitExternal {
+					merged[j].DiscoveredPath = s.discoveredPath
+					merged[j].External = s.external
+				}
+				if len(cfg.TagSets) > 0 {
+					merged[j].TagSets = []string{tags}
+				}
+			}
+			merged[j].Benchmarks = appendNewFuncs(merged[j].Benchmarks, s.benchmarks)
+			merged[j].Fuzz = appendNewFuncs(merged[j].Fuzz, s.fuzz)
+			if merged[j].Dir == "" {
+				merged[j].Dir = s.dir
+			}
+			for _, tag := range s.requiredTags {
+				if !slices.Contains(merged[j].RequiredTags, tag) {
+					merged[j].RequiredTags = append(merged[j].RequiredTags, tag)
+				}
+			}
+		}
+		for _, pkg := range merged {
+			// Sort here too so streamed output is as deterministic as
+			// the batch form within a package.
+			slices.SortFunc(pkg.Benchmarks, byName)
+			slices.SortFunc(pkg.Fuzz, byName)
+			slices.Sort(pkg.RequiredTags)
+			if err := emit(pkg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scannedPkg is the outcome of scanning a single loaded package variant.
+type scannedPkg struct {
+	path string
+	// discoveredPath is the import path the variant was loaded under,
+	// keeping the _test suffix that path has trimmed off.
+	discoveredPath string
+	external       bool
+	dir            string
+	benchmarks     []TestFunc
+	fuzz           []TestFunc
+	malformed      []TestFunc
+	requiredTags   []string
+}
+
+// scanPackages loads the patterns under a single build tag set and scans the
+// matching packages for test functions of the given kind.
+func scanPackages(cfg Config, kind string, patterns []string, tags string) ([]scannedPkg, error) {
+	loadCfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedSyntax,
+		Dir:   cfg.Dir,
+		Tests: true,
+	}
+	if tags != "" {
+		loadCfg.BuildFlags = []string{"-tags=" + tags}
+	}
+	pkgs, err := packages.Load(loadCfg, patterns...)
+	if err != nil {
+		return nil, trace.Wrap(err, "loading packages")
+	}
+	broken := make(map[*packages.Package]bool)
+	if cfg.OnLoadError != nil {
+		packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+			for _, pkgErr := range pkg.Errors {
+				cfg.OnLoadError(pkgErr)
+				broken[pkg] = true
+			}
+		})
+	} else if packages.PrintErrors(pkgs) > 0 {
+		return nil, trace.BadParameter("failed to load some packages")
+	}
+
+	// Collect the packages to scan in traversal order first, applying the
+	// path filters, so that the concurrent scan below can index results by
+	// discovery position and keep the output order deterministic.
+	type candidate struct {
+		path string
+		pkg  *packages.Package
+	}
+	var candidates []candidate
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if broken[pkg] {
+			return
+		}
+		path := normalizePkgPath(pkg)
+		if path == "" {
+			return
+		}
+		// The include allowlist runs before the exclude denylist, so an
+		// exclude can carve packages out of an included subtree.
+		if len(cfg.Includes) > 0 && !matchesAnyPattern(path, cfg.Includes) {
+			return
+		}
+		if matchesAnyPattern(path, cfg.Excludes) {
+			return
+		}
+		candidates = append(candidates, candidate{path: path, pkg: pkg})
+	})
+
+	// Scan the syntax trees with a bounded worker pool. Each worker writes
+	// only to its own slot, so no locking is needed.
+	jobs := cfg.Jobs
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	scanned := make([]scannedPkg, len(candidates))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, jobs)
+	for i, c := range candidates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			benchmarks, fuzz, malformed, requiredTags := packageTestFuncs(c.pkg, kind, cfg.OnMalformed != nil, cfg.CollectRequiredTags)
+			scanned[i] = scannedPkg{
+				path:           c.path,
+				discoveredPath: c.pkg.PkgPath,
+				external:       strings.HasSuffix(c.pkg.PkgPath, "_test"),
+				dir:            packageDir(c.pkg),
+				benchmarks:     benchmarks,
+				fuzz:           fuzz,
+				malformed:      malformed,
+				requiredTags:   requiredTags,
+			}
+		}()
+	}
+	wg.Wait()
+	return scanned, nil
+}
+
+// appendNewFuncs appends the functions from src that are not already present
+// in dst by name, so that the same package loaded under several tag sets does
+// not report its functions more than once.
+func appendNewFuncs(dst, src []TestFunc) []TestFunc {
+	for _, fn := range src {
+		if slices.ContainsFunc(dst, func(existing TestFunc) bool { return existing.Name == fn.Name }) {
+			continue
+		}
+		dst = append(dst, fn)
+	}
+	return dst
+}
+
+// packageDir returns the directory containing the package's Go files, or an
+// empty string when the loaded package has none. External test packages only
+// carry the _test.go files, so the directory may come from another variant of
+// the same package.
+func packageDir(pkg *packages.Package) string {
+	if len(pkg.GoFiles) == 0 {
+		return ""
+	}
+	return filepath.Dir(pkg.GoFiles[0])
+}
+
+// normalizePkgPath returns the import path of the real package a loaded
+// package belongs to, or an empty string if the package should be skipped.
+// With test loading enabled a package can show up as itself, as a test
+// variant with the same import path, as an external test package with an
+// _test suffix and as a synthesized test main package with a .test suffix.
+func normalizePkgPath(pkg *packages.Package) string {
+	path := pkg.PkgPath
+	if strings.HasSuffix(path, ".test") {
+		return ""
+	}
+	return strings.TrimSuffix(path, "_test")
+}
+
+// matchesAnyPattern reports whether the import path matches any of the given
+// patterns. Patterns containing glob metacharacters are matched as
+// doublestar globs, where * matches within a path segment and ** spans
+// segments. Plain patterns keep the original prefix-match semantics.
+func matchesAnyPattern(path string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if isGlobPattern(pattern) {
+			// Match errors only on malformed patterns, which never match.
+			if ok, err := doublestar.Match(pattern, path); err == nil && ok {
+				return true
+			}
+			continue
+		}
+		if strings.HasPrefix(path, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGlobPattern reports whether the pattern contains any glob
+// metacharacters understood by [doublestar.Match].
+func isGlobPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[{")
+}
+
+// IgnoreFileName is the name of the per-tree ignore file consulted by
+// discovery. It lives in [Config.Dir] and holds one exclude pattern per
+// line, in the same prefix or glob form as [Config.Excludes], with blank
+// lines and # comments skipped.
+const IgnoreFileName = ".benchfindignore"
+
+// readIgnoreFile reads the exclude patterns from the ignore file in dir.
+// A missing file is not an error and yields no patterns.
+func readIgnoreFile(dir string) ([]string, error) {
+	if dir == "" {
+		dir = "."
+	}
+	f, err := os.Open(filepath.Join(dir, IgnoreFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, trace.Wrap(err, "opening ignore file")
+	}
+	defer f.Close()
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, trace.Wrap(err, "reading ignore file")
+	}
+	return patterns, nil
+}
+
+// packageTestFuncs returns the top-level benchmark functions and fuzz targets
+// matching kind that are declared in the package's syntax trees, along with
+// malformed benchmark candidates when collectMalformed is set. With
+// collectTags set it also reports the build tags required by the //go:build
+// constraints of the files declaring the discovered functions.
+func packageTestFuncs(pkg *packages.Package, kind string, collectMalformed, collectTags bool) (benchmarks, fuzz, malformed []TestFunc, requiredTags []string) {
+	for _, file := range pkg.Syntax {
+		fileBenchmarks, fileFuzz, fileMalformed := fileTestFuncs(pkg.Fset, file, kind, collectMalformed)
+		benchmarks = append(benchmarks, fileBenchmarks...)
+		fuzz = append(fuzz, fileFuzz...)
+		malformed = append(malformed, fileMalformed...)
+		if collectTags && len(fileBenchmarks)+len(fileFuzz) > 0 {
+			for _, tag := range fileRequiredTags(file) {
+				if !slices.Contains(requiredTags, tag) {
+					requiredTags = append(requiredTags, tag)
+				}
+			}
+		}
+	}
+	return benchmarks, fuzz, malformed, requiredTags
+}
+
+// fileRequiredTags returns the build tags referenced positively by the
+// file's //go:build constraint, if it has one. Negated tags are skipped:
+// they must stay unset rather than be passed via -tags. Tags from both
+// branches of an || are reported, which over-approximates for constraints
+// satisfiable several ways, and file-name based constraints like _linux.go
+// are not considered.
+func fileRequiredTags(file *ast.File) []string {
+	var tags []string
+	for _, group := range file.Comments {
+		// Only comments before the package clause can carry a constraint.
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if !constraint.IsGoBuild(comment.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(comment.Text)
+			if err != nil {
+				continue
+			}
+			tags = append(tags, positiveConstraintTags(expr)...)
+		}
+	}
+	return tags
+}
+
+// positiveConstraintTags collects the tags referenced without negation
+// anywhere in the constraint expression.
+func positiveConstraintTags(expr constraint.Expr) []string {
+	switch e := expr.(type) {
+	case *constraint.TagExpr:
+		return []string{e.Tag}
+	case *constraint.AndExpr:
+		return append(positiveConstraintTags(e.X), positiveConstraintTags(e.Y)...)
+	case *constraint.OrExpr:
+		return append(positiveConstraintTags(e.X), positiveConstraintTags(e.Y)...)
+	default:
+		// Negations contribute nothing: those tags must stay unset.
+		return nil
+	}
+}
+
+// fileTestFuncs returns the benchmark functions and fuzz targets matching
+// kind that are declared in a single file, along with malformed benchmark
+// candidates when collectMalformed is set.
+func fileTestFuncs(fset *token.FileSet, file *ast.File, kind string, collectMalformed bool) (benchmarks, fuzz, malformed []TestFunc) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		switch {
+		case kind != KindFuzz && isBenchmark(fn):
+			benchmarks = append(benchmarks, newTestFunc(fset, fn))
+		case kind != KindBenchmark && isFuzz(fn):
+			fuzz = append(fuzz, newTestFunc(fset, fn))
+		case collectMalformed && hasBenchmarkPrefix(fn) && !isBenchmark(fn):
+			malformed = append(malformed, newTestFunc(fset, fn))
+		}
+	}
+	return benchmarks, fuzz, malformed
+}
+
+// hasBenchmarkPrefix reports whether the function looks like it was intended
+// to be a benchmark, regardless of whether the test runner would accept it.
+func hasBenchmarkPrefix(fn *ast.FuncDecl) bool {
+	return strings.HasPrefix(fn.Name.Name, "Benchmark")
+}
+
+// newTestFunc builds a TestFunc for a function declaration, resolving its
+// file:line location through the package's file set.
+func newTestFunc(fset *token.FileSet, fn *ast.FuncDecl) TestFunc {
+	pos := fset.Position(fn.Pos())
+	return TestFunc{
+		Name:       fn.Name.Name,
+		Location:   fmt.Sprintf("%s:%d", pos.Filename, pos.Line),
+		LegacyLoop: usesLegacyLoop(fn),
+		Generic:    fn.Type.TypeParams != nil,
+	}
+}
+
+// usesLegacyLoop reports whether the benchmark body references the b.N
+// counter without ever calling b.Loop. This needs to inspect the function
+// body rather than just the signature: a benchmark migrated to Go 1.24's
+// b.Loop style no longer mentions b.N at all.
+func usesLegacyLoop(fn *ast.FuncDecl) bool {
+	if fn.Body == nil || len(fn.Type.Params.List) == 0 || len(fn.Type.Params.List[0].Names) == 0 {
+		return false
+	}
+	param := fn.Type.Params.List[0].Names[0].Name
+	var refsN, callsLoop bool
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := sel.X.(*ast.Ident); !ok || ident.Name != param {
+			return true
+		}
+		switch sel.Sel.Name {
+		case "N":
+			refsN = true
+		case "Loop":
+			callsLoop = true
+		}
+		return true
+	})
+	return refsN && !callsLoop
+}
+
+// isBenchmark reports whether fn is a benchmark function that the go test
+// runner would pick up: a top-level func named BenchmarkXxx taking a single
+// *testing.B parameter and returning nothing.
+func isBenchmark(fn *ast.FuncDecl) bool {
+	if !isTestFuncName(fn.Name.Name, "Benchmark") {
+		return false
+	}
+	if fn.Recv != nil {
+		return false
+	}
+	// Declarations with type parameters are deliberately accepted even
+	// though the test runner skips them: a generic benchmark is almost
+	// always one awaiting a non-generic wrapper, so it is reported and
+	// flagged via [TestFunc.Generic] rather than silently dropped.
+	return hasSingleParamOfType(fn.Type, "testing", "B")
+}
+
+// isFuzz reports whether fn is a fuzz target that the go test runner would
+// pick up: a top-level func named FuzzXxx taking a single *testing.F
+// parameter and returning nothing.
+func isFuzz(fn *ast.FuncDecl) bool {
+	if !isTestFuncName(fn.Name.Name, "Fuzz") {
+		return false
+	}
+	if fn.Recv != nil {
+		return false
+	}
+	return hasSingleParamOfType(fn.Type, "testing", "F")
+}
+
+// isTestFuncName reports whether name matches the test runner's naming rule
+// for the given prefix: the prefix alone, or the prefix followed by a
+// character that is not lowercase. BenchmarkX is a benchmark name,
+// Benchmarkx is not.
+func isTestFuncName(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	suffix := name[len(prefix):]
+	if suffix == "" {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(suffix)
+	return !unicode.IsLower(r)
+}
+
+// hasSingleParamOfType reports whether the function type takes exactly one
+// parameter that is a pointer to the named package-level type and has no
+// results.
+func hasSingleParamOfType(fnType *ast.FuncType, pkgName, typeName string) bool {
+	if fnType.Results != nil && len(fnType.Results.List) > 0 {
+		return false
+	}
+	params := fnType.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 {
+		return false
+	}
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == pkgName && sel.Sel.Name == typeName
+}
@@ -0,0 +1,449 @@
+/*
+ * Teleport
+ * Copyright (C) 2026  Gravitational, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package benchfind
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gravitational/trace"
+	"github.com/stretchr/testify/require"
+)
+
+const testdataPkgPrefix = "github.com/gravitational/teleport/build.assets/tooling/lib/benchfind/testdata/src/"
+
+// funcNames strips locations from discovered functions so tests can compare
+// names without depending on absolute testdata paths.
+func funcNames(funcs []TestFunc) []string {
+	if len(funcs) == 0 {
+		return nil
+	}
+	names := make([]string, len(funcs))
+	for i, fn := range funcs {
+		names[i] = fn.Name
+	}
+	return names
+}
+
+func TestFind(t *testing.T) {
+	pkgs, err := Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./bench", "./nobench", "./exttest"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pkgs, 2)
+	require.Equal(t, testdataPkgPrefix+"bench", pkgs[0].Path)
+	require.Equal(t, []string{"BenchmarkEncode", "BenchmarkParse"}, funcNames(pkgs[0].Benchmarks))
+	require.Equal(t, testdataPkgPrefix+"exttest", pkgs[1].Path)
+	require.Equal(t, []string{"BenchmarkExternal"}, funcNames(pkgs[1].Benchmarks))
+
+	// Locations point at the declaration in the source file.
+	loc := pkgs[1].Benchmarks[0].Location
+	require.True(t, strings.HasSuffix(loc, filepath.Join("exttest", "ext_test.go")+":5"), "unexpected location %q", loc)
+}
+
+func TestFindKinds(t *testing.T) {
+	for _, tc := range []struct {
+		kind       string
+		benchmarks []string
+		fuzz       []string
+	}{
+		{
+			kind:       KindBenchmark,
+			benchmarks: []string{"BenchmarkDecode"},
+		},
+		{
+			kind: KindFuzz,
+			fuzz: []string{"FuzzDecode"},
+		},
+		{
+			kind:       KindBoth,
+			benchmarks: []string{"BenchmarkDecode"},
+			fuzz:       []string{"FuzzDecode"},
+		},
+	} {
+		t.Run(tc.kind, func(t *testing.T) {
+			pkgs, err := Find(Config{
+				Dir:      filepath.Join("testdata", "src"),
+				Patterns: []string{"./fuzzy", "./nobench"},
+				Kind:     tc.kind,
+			})
+			require.NoError(t, err)
+			require.Len(t, pkgs, 1)
+			require.Equal(t, testdataPkgPrefix+"fuzzy", pkgs[0].Path)
+			require.Equal(t, tc.benchmarks, funcNames(pkgs[0].Benchmarks))
+			require.Equal(t, tc.fuzz, funcNames(pkgs[0].Fuzz))
+		})
+	}
+}
+
+func TestFindGenericBenchmarks(t *testing.T) {
+	// Generic declarations are reported alongside regular benchmarks but
+	// flagged, since the test runner skips them until they get a
+	// non-generic wrapper.
+	pkgs, err := Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./generic"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pkgs, 1)
+	require.Equal(t, []string{"BenchmarkPlain", "BenchmarkTyped"}, funcNames(pkgs[0].Benchmarks))
+	for _, fn := range pkgs[0].Benchmarks {
+		require.Equal(t, fn.Name == "BenchmarkTyped", fn.Generic, "unexpected Generic flag on %s", fn.Name)
+	}
+}
+
+func TestFindExcludes(t *testing.T) {
+	pkgs, err := Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./bench", "./exttest"},
+		Excludes: []string{testdataPkgPrefix + "ext"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pkgs, 1)
+	require.Equal(t, testdataPkgPrefix+"bench", pkgs[0].Path)
+}
+
+func TestFindIgnoreFile(t *testing.T) {
+	// The ignore file in cfg.Dir contributes exclude patterns on top of
+	// cfg.Excludes; comments and blank lines are skipped.
+	dir := filepath.Join("testdata", "src")
+	ignoreFile := filepath.Join(dir, IgnoreFileName)
+	require.NoError(t, os.WriteFile(ignoreFile, []byte("# generated fixtures\n\n"+testdataPkgPrefix+"exttest\n"), 0o644))
+	t.Cleanup(func() { require.NoError(t, os.Remove(ignoreFile)) })
+
+	pkgs, err := Find(Config{
+		Dir:      dir,
+		Patterns: []string{"./bench", "./exttest", "./fuzzy"},
+		Excludes: []string{testdataPkgPrefix + "fuzzy"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pkgs, 1)
+	require.Equal(t, testdataPkgPrefix+"bench", pkgs[0].Path)
+}
+
+func TestFindIncludes(t *testing.T) {
+	// The include allowlist keeps only matching packages, and excludes still
+	// apply on top of it.
+	pkgs, err := Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./bench", "./exttest", "./fuzzy"},
+		Includes: []string{testdataPkgPrefix + "bench", testdataPkgPrefix + "exttest"},
+		Excludes: []string{testdataPkgPrefix + "exttest"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pkgs, 1)
+	require.Equal(t, testdataPkgPrefix+"bench", pkgs[0].Path)
+}
+
+func TestFindSortedOutput(t *testing.T) {
+	// Listing the patterns out of order must not affect the result: packages
+	// are sorted by import path, so repeated runs diff cleanly.
+	cfg := Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./fuzzy", "./exttest", "./bench"},
+		Kind:     KindBoth,
+	}
+	first, err := Find(cfg)
+	require.NoError(t, err)
+	require.True(t, slices.IsSortedFunc(first, func(a, b Package) int {
+		return strings.Compare(a.Path, b.Path)
+	}))
+
+	second, err := Find(cfg)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+}
+
+func TestFindTagSets(t *testing.T) {
+	// Without the tag the benchmark file is excluded by its build constraint.
+	pkgs, err := Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./tagged", "./bench"},
+	})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Equal(t, testdataPkgPrefix+"bench", pkgs[0].Path)
+
+	// With two tag sets the results are unioned without duplicating the
+	// functions of packages that surface under both, and each package records
+	// the tag sets it surfaced under.
+	pkgs, err = Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./tagged", "./bench"},
+		TagSets:  []string{"tagbench", "othertag"},
+	})
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2)
+	require.Equal(t, testdataPkgPrefix+"bench", pkgs[0].Path)
+	require.Equal(t, []string{"BenchmarkEncode", "BenchmarkParse"}, funcNames(pkgs[0].Benchmarks))
+	require.Equal(t, []string{"tagbench", "othertag"}, pkgs[0].TagSets)
+	require.Equal(t, testdataPkgPrefix+"tagged", pkgs[1].Path)
+	require.Equal(t, []string{"BenchmarkTagged"}, funcNames(pkgs[1].Benchmarks))
+	require.Equal(t, []string{"tagbench"}, pkgs[1].TagSets)
+}
+
+func TestFindEach(t *testing.T) {
+	cfg := Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./bench", "./nobench", "./exttest", "./fuzzy"},
+		Kind:     KindBoth,
+	}
+	want, err := Find(cfg)
+	require.NoError(t, err)
+	require.NotEmpty(t, want)
+
+	// Streaming yields the same packages as the batch form, just in
+	// traversal order instead of sorted order.
+	var streamed []Package
+	require.NoError(t, FindEach(cfg, func(pkg Package) error {
+		streamed = append(streamed, pkg)
+		return nil
+	}))
+	slices.SortFunc(streamed, func(a, b Package) int { return strings.Compare(a.Path, b.Path) })
+	require.Equal(t, want, streamed)
+
+	// An error returned by the callback stops the run and surfaces as is.
+	sentinel := trace.BadParameter("stop")
+	var calls int
+	err = FindEach(cfg, func(Package) error {
+		calls++
+		return sentinel
+	})
+	require.ErrorIs(t, err, sentinel)
+	require.Equal(t, 1, calls)
+}
+
+func TestFindRequiredTags(t *testing.T) {
+	pkgs, err := Find(Config{
+		Dir:                 filepath.Join("testdata", "src"),
+		Patterns:            []string{"./tagged", "./bench"},
+		TagSets:             []string{"tagbench"},
+		CollectRequiredTags: true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pkgs, 2)
+	// Unconstrained packages report no required tags, constrained ones the
+	// tags their //go:build lines reference.
+	require.Equal(t, testdataPkgPrefix+"bench", pkgs[0].Path)
+	require.Empty(t, pkgs[0].RequiredTags)
+	require.Equal(t, testdataPkgPrefix+"tagged", pkgs[1].Path)
+	require.Equal(t, []string{"tagbench"}, pkgs[1].RequiredTags)
+}
+
+func TestPositiveConstraintTags(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		want []string
+	}{
+		{line: "//go:build tagbench", want: []string{"tagbench"}},
+		{line: "//go:build a && b", want: []string{"a", "b"}},
+		{line: "//go:build a || b", want: []string{"a", "b"}},
+		{line: "//go:build a && !b", want: []string{"a"}},
+		{line: "//go:build !a", want: nil},
+	} {
+		expr, err := constraint.Parse(tc.line)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, positiveConstraintTags(expr), "constraint %q", tc.line)
+	}
+}
+
+func TestFindParallelMatchesSerial(t *testing.T) {
+	cfg := Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./bench", "./nobench", "./exttest", "./fuzzy"},
+		Kind:     KindBoth,
+	}
+
+	cfg.Jobs = 1
+	serial, err := Find(cfg)
+	require.NoError(t, err)
+	require.NotEmpty(t, serial)
+
+	cfg.Jobs = 8
+	parallel, err := Find(cfg)
+	require.NoError(t, err)
+	require.Equal(t, serial, parallel)
+}
+
+func TestFindBestEffort(t *testing.T) {
+	// Strict mode fails the whole run when any package fails to load.
+	_, err := Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./broken", "./bench"},
+	})
+	require.Error(t, err)
+
+	// With an OnLoadError callback the broken package is skipped and the
+	// clean ones still report their benchmarks.
+	var loadErrs []error
+	pkgs, err := Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./broken", "./bench"},
+		OnLoadError: func(err error) {
+			loadErrs = append(loadErrs, err)
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, loadErrs)
+	require.Len(t, pkgs, 1)
+	require.Equal(t, testdataPkgPrefix+"bench", pkgs[0].Path)
+}
+
+func TestLegacyLoopDetection(t *testing.T) {
+	pkgs, err := Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./bloop"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pkgs, 1)
+	legacy := map[string]bool{}
+	for _, benchmark := range pkgs[0].Benchmarks {
+		legacy[benchmark.Name] = benchmark.LegacyLoop
+	}
+	require.Equal(t, map[string]bool{
+		"BenchmarkModern": false,
+		"BenchmarkLegacy": true,
+	}, legacy)
+}
+
+func TestMalformedDetection(t *testing.T) {
+	var malformed []string
+	_, err := Find(Config{
+		Dir:      filepath.Join("testdata", "src"),
+		Patterns: []string{"./bench", "./nobench"},
+		OnMalformed: func(pkgPath string, fn TestFunc) {
+			require.Equal(t, testdataPkgPrefix+"bench", pkgPath)
+			require.NotEmpty(t, fn.Location)
+			malformed = append(malformed, fn.Name)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Benchmarkhelper", "Benchmarksetup"}, malformed)
+}
+
+func TestMatchesAnyPattern(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		patterns []string
+		expected bool
+	}{
+		// Plain prefixes keep the original semantics.
+		{path: "lib/join/messages", patterns: []string{"lib/join"}, expected: true},
+		{path: "lib/joinery", patterns: []string{"lib/join"}, expected: true},
+		{path: "api/types", patterns: []string{"lib/join"}, expected: false},
+		// Single-segment globs do not span path separators.
+		{path: "lib/fixtures", patterns: []string{"lib/*"}, expected: true},
+		{path: "lib/join/fixtures", patterns: []string{"lib/*"}, expected: false},
+		// Doublestar globs span any number of segments.
+		{path: "lib/join/fixtures", patterns: []string{"lib/**/fixtures"}, expected: true},
+		{path: "lib/a/b/c/fixtures", patterns: []string{"lib/**/fixtures"}, expected: true},
+		{path: "lib/join/fixtures/deep", patterns: []string{"lib/**/fixtures"}, expected: false},
+		// The first matching pattern wins.
+		{path: "api/mocks", patterns: []string{"lib", "api/**/mocks", "*/mocks"}, expected: true},
+		{path: "", patterns: nil, expected: false},
+	} {
+		require.Equal(t, tc.expected, matchesAnyPattern(tc.path, tc.patterns),
+			"path %q patterns %v", tc.path, tc.patterns)
+	}
+}
+
+func TestIsBenchmark(t *testing.T) {
+	const src = `package example
+
+import "testing"
+
+func BenchmarkValid(b *testing.B) {}
+
+func BenchmarkWrongParam(t *testing.T) {}
+
+func BenchmarkTooManyParams(b *testing.B, n int) {}
+
+func BenchmarkWithResult(b *testing.B) error { return nil }
+
+func NotABenchmark(b *testing.B) {}
+
+func Benchmarklower(b *testing.B) {}
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "example_test.go", src, 0)
+	require.NoError(t, err)
+
+	expected := map[string]bool{
+		"BenchmarkValid":         true,
+		"BenchmarkWrongParam":    false,
+		"BenchmarkTooManyParams": false,
+		"BenchmarkWithResult":    false,
+		"NotABenchmark":          false,
+		"Benchmarklower":         false,
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		require.Equal(t, expected[fn.Name.Name], isBenchmark(fn), "function %v", fn.Name.Name)
+	}
+}
+
+func TestFindSplitExternal(t *testing.T) {
+	cfg := Config{
+		Dir:           filepath.Join("testdata", "src"),
+		Patterns:      []string{"./mixed"},
+		SplitExternal: true,
+	}
+	pkgs, err := Find(cfg)
+	require.NoError(t, err)
+
+	// The internal and external variants stay separate entries sharing the
+	// normalized import path, internal first.
+	require.Len(t, pkgs, 2)
+	require.Equal(t, testdataPkgPrefix+"mixed", pkgs[0].Path)
+	require.Equal(t, testdataPkgPrefix+"mixed", pkgs[0].DiscoveredPath)
+	require.False(t, pkgs[0].External)
+	require.Equal(t, []string{"BenchmarkInternal"}, funcNames(pkgs[0].Benchmarks))
+	require.Equal(t, testdataPkgPrefix+"mixed", pkgs[1].Path)
+	require.Equal(t, testdataPkgPrefix+"mixed_test", pkgs[1].DiscoveredPath)
+	require.True(t, pkgs[1].External)
+	require.Equal(t, []string{"BenchmarkExternal"}, funcNames(pkgs[1].Benchmarks))
+
+	// Without the option the variants merge into one entry as before, with
+	// no discovered path reported.
+	cfg.SplitExternal = false
+	pkgs, err = Find(cfg)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	require.Equal(t, testdataPkgPrefix+"mixed", pkgs[0].Path)
+	require.Empty(t, pkgs[0].DiscoveredPath)
+	require.False(t, pkgs[0].External)
+	require.Equal(t, []string{"BenchmarkExternal", "BenchmarkInternal"}, funcNames(pkgs[0].Benchmarks))
+}
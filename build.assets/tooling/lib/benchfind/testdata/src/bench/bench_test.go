@@ -0,0 +1,25 @@
+package bench
+
+import "testing"
+
+func TestSomething(t *testing.T) {}
+
+func BenchmarkParse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+	}
+}
+
+// Benchmarkhelper is deliberately not picked up by the test runner: the
+// character after the Benchmark prefix is lowercase.
+func Benchmarkhelper(b *testing.B) {}
+
+// Benchmarksetup is doubly malformed: lowercase after the prefix and no
+// parameters at all. The lowercase is what lets the package still
+// type-check — a properly-cased BenchmarkSetup() fails the go toolchain's
+// signature check at load time and takes the whole package with it.
+func Benchmarksetup() {}
@@ -0,0 +1,13 @@
+package bloop
+
+import "testing"
+
+func BenchmarkModern(b *testing.B) {
+	for b.Loop() {
+	}
+}
+
+func BenchmarkLegacy(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+	}
+}
@@ -0,0 +1,2 @@
+// Package exttest only has benchmarks in its external test package.
+package exttest
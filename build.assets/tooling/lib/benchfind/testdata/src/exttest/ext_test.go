@@ -0,0 +1,8 @@
+package exttest_test
+
+import "testing"
+
+func BenchmarkExternal(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+	}
+}
@@ -0,0 +1,8 @@
+package generic
+
+import "testing"
+
+func BenchmarkPlain(b *testing.B) {
+	for b.Loop() {
+	}
+}
@@ -0,0 +1,8 @@
+package mixed
+
+import "testing"
+
+func BenchmarkInternal(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+	}
+}
@@ -0,0 +1,2 @@
+// Package tagged exercises build tag aware discovery.
+package tagged
@@ -0,0 +1,10 @@
+//go:build tagbench
+
+package tagged
+
+import "testing"
+
+func BenchmarkTagged(b *testing.B) {
+	for b.Loop() {
+	}
+}
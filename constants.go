@@ -350,6 +350,9 @@ const (
 	// Text means text serialization format
 	Text = "text"
 
+	// CSV means comma-separated values serialization format
+	CSV = "csv"
+
 	// PTY is a raw PTY session capture format
 	PTY = "pty"
 
@@ -301,6 +301,11 @@ type RegisterResult struct {
 	// BoundKeypair contains additional results from bound keypair registration
 	// attempts. This is only set when bound keypair joining is used.
 	BoundKeypair *BoundKeypairRegisterResult
+	// JoinMethod is the join method that was actually used, as resolved and
+	// validated by the server, so that callers can log or audit it. An empty
+	// value means the method is unknown: the legacy register flow predates
+	// the server reporting it.
+	JoinMethod types.JoinMethod
 }
 
 // Register is used to get signed certificates when a node, proxy, or bot is
@@ -55,6 +55,9 @@ type ConnectionConfig struct {
 	Insecure bool
 	// Log is the logger.
 	Log *slog.Logger
+	// DialOpts are additional gRPC dial options applied on top of the
+	// defaults, e.g. keepalive parameters for long-running streams.
+	DialOpts []grpc.DialOption
 }
 
 // NewConnection attempts to connect to the proxy insecure grpc server.
@@ -83,15 +86,17 @@ func NewConnection(
 		client.WithALPNConnUpgrade(alpnConnUpgrade),
 	)
 
-	//nolint:staticcheck // ignore deprecation until https://github.com/grpc/grpc-go/issues/7556 is fixed, at which point we should switch to grpc.NewClient.
-	conn, err := grpc.Dial(
-		params.ProxyServer,
+	dialOpts := []grpc.DialOption{
 		grpc.WithContextDialer(client.GRPCContextDialer(dialer)),
 		grpc.WithChainUnaryInterceptor(metadata.UnaryClientInterceptor, interceptors.GRPCClientUnaryErrorInterceptor),
 		grpc.WithChainStreamInterceptor(metadata.StreamClientInterceptor, interceptors.GRPCClientStreamErrorInterceptor),
 		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
-	)
+	}
+	dialOpts = append(dialOpts, params.DialOpts...)
+
+	//nolint:staticcheck // ignore deprecation until https://github.com/grpc/grpc-go/issues/7556 is fixed, at which point we should switch to grpc.NewClient.
+	conn, err := grpc.Dial(params.ProxyServer, dialOpts...)
 	return conn, trace.Wrap(err)
 }
 
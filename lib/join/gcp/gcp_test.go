@@ -19,9 +19,12 @@
 package gcp
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
 )
 
 func TestIsGCPZoneInLocation(t *testing.T) {
@@ -95,3 +98,60 @@ func TestIsGCPZoneInLocation(t *testing.T) {
 		})
 	}
 }
+
+type staticValidator struct {
+	claims *IDTokenClaims
+}
+
+func (v *staticValidator) Validate(_ context.Context, _ string) (*IDTokenClaims, error) {
+	return v.claims, nil
+}
+
+func TestCheckIDTokenParamsValidate(t *testing.T) {
+	t.Parallel()
+
+	validParams := func() *CheckIDTokenParams {
+		return &CheckIDTokenParams{
+			ProvisionToken: &types.ProvisionTokenV2{},
+			IDToken:        []byte("test.fake.jwt"),
+			Validator:      &staticValidator{},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		modifier func(*CheckIDTokenParams)
+		wantErr  string
+	}{
+		{
+			name:     "ok",
+			modifier: func(*CheckIDTokenParams) {},
+		},
+		{
+			name:     "missing provision token",
+			modifier: func(p *CheckIDTokenParams) { p.ProvisionToken = nil },
+			wantErr:  "ProvisionToken is required",
+		},
+		{
+			name:     "missing id token",
+			modifier: func(p *CheckIDTokenParams) { p.IDToken = nil },
+			wantErr:  "IDToken is required",
+		},
+		{
+			name:     "missing validator",
+			modifier: func(p *CheckIDTokenParams) { p.Validator = nil },
+			wantErr:  "Validator is required",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			params := validParams()
+			tc.modifier(params)
+			err := params.validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
@@ -213,6 +213,13 @@ type OIDCInit struct {
 	IDToken []byte
 }
 
+func (i *OIDCInit) Check() error {
+	if len(i.IDToken) == 0 {
+		return trace.BadParameter("IDToken is required")
+	}
+	return trace.Wrap(i.ClientParams.check(), "checking ClientParams")
+}
+
 // BoundKeypairInit is sent from the client in response to the ServerInit
 // message for the bound keypair join method.
 // The server is expected to respond with a BoundKeypairChallenge.
@@ -499,8 +506,8 @@ type AzureChallenge struct {
 	Challenge string
 }
 
-// AzureChallenge message.
 // AzureChallengeSolution must be sent from the client in response to the
+// AzureChallenge message.
 type AzureChallengeSolution struct {
 	embedRequest
 
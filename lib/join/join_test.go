@@ -380,6 +380,39 @@ func TestJoinToken(t *testing.T) {
 	})
 }
 
+// TestJoinOutOfOrderFirstMessage asserts that the server rejects a join
+// stream where the first message is not a ClientInit.
+func TestJoinOutOfOrderFirstMessage(t *testing.T) {
+	t.Parallel()
+
+	authService := newFakeAuthService(t)
+	unauthenticatedAuthClt, err := authService.NewClient(authtest.TestNop())
+	require.NoError(t, err)
+
+	stream, err := unauthenticatedAuthClt.JoinV1Client().Join(t.Context())
+	require.NoError(t, err)
+
+	// Send a valid TokenInit message, which is only expected later in the
+	// sequence for the token join method, instead of the mandatory ClientInit.
+	require.NoError(t, stream.Send(&joinv1proto.JoinRequest{
+		Payload: &joinv1proto.JoinRequest_TokenInit{
+			TokenInit: &joinv1proto.TokenInit{
+				ClientParams: &joinv1proto.ClientParams{
+					Payload: &joinv1proto.ClientParams_HostParams{
+						HostParams: &joinv1proto.HostParams{
+							HostName: "node",
+						},
+					},
+				},
+			},
+		},
+	}))
+
+	_, err = stream.Recv()
+	require.True(t, trace.IsBadParameter(trace.Unwrap(err)), "expected BadParameter error, got %v", err)
+	require.ErrorContains(t, err, "expected client to send message of type *messages.ClientInit")
+}
+
 // TestJoinError asserts that attempts to join with an invalid token return an
 // AccessDenied error and do not fall back to joining via the legacy join
 // service.
@@ -572,6 +605,8 @@ func (p *fakeProxy) join(t *testing.T) {
 		AdditionalPrincipals: []string{"127.0.0.1"},
 	})
 	require.NoError(t, err)
+	// The result reports the join method the server resolved from the token.
+	require.Equal(t, types.JoinMethodToken, joinResult.JoinMethod)
 
 	privateKeyPEM, err := keys.MarshalPrivateKey(joinResult.PrivateKey)
 	require.NoError(t, err)
@@ -27,6 +27,7 @@ import (
 
 	"github.com/gravitational/trace"
 	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc"
 
 	"github.com/gravitational/teleport/api/client/proto"
 	"github.com/gravitational/teleport/api/types"
@@ -174,6 +175,10 @@ func joinViaProxy(ctx context.Context, params JoinParams, proxyAddr string) (*Jo
 			Clock:        params.Clock,
 			Insecure:     params.Insecure,
 			Log:          slog.Default(),
+			// Challenge-based joins can leave the stream idle while proofs
+			// are gathered; transport keepalives stop intermediaries from
+			// dropping the connection in the meantime.
+			DialOpts: []grpc.DialOption{joinv1.KeepaliveDialOption(0)},
 		},
 	)
 	if err != nil {
@@ -241,6 +246,9 @@ func joinWithClient(ctx context.Context, params JoinParams, client *joinv1.Clien
 		}
 		return nil, err
 	}
+	if err := checkServerJoinMethod(params.JoinMethod, serverInit); err != nil {
+		return nil, trace.Wrap(err)
+	}
 
 	// Generate keys based on the signature algorithm suite from the ServerInit message.
 	signer, publicKeys, err := GenerateKeys(ctx, serverInit.SignatureAlgorithmSuite)
@@ -257,11 +265,15 @@ func joinWithClient(ctx context.Context, params JoinParams, client *joinv1.Clien
 	}
 
 	// Convert the result message into a JoinResult.
+	var joinResult *JoinResult
 	switch typedResult := resultMsg.(type) {
 	case *messages.HostResult:
-		return makeJoinResult(signer, typedResult.Certificates)
+		joinResult, err = makeJoinResult(signer, typedResult.Certificates)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
 	case *messages.BotResult:
-		joinResult, err := makeJoinResult(signer, typedResult.Certificates)
+		joinResult, err = makeJoinResult(signer, typedResult.Certificates)
 		if err != nil {
 			return nil, trace.Wrap(err)
 		}
@@ -271,10 +283,25 @@ func joinWithClient(ctx context.Context, params JoinParams, client *joinv1.Clien
 				JoinState:      typedResult.BoundKeypairResult.JoinState,
 			}
 		}
-		return joinResult, nil
 	default:
 		return nil, trace.BadParameter("unhandled result message type %T", resultMsg)
 	}
+	// Report the join method the server actually selected and validated,
+	// which is more trustworthy for audit purposes than the method the
+	// client requested.
+	joinResult.JoinMethod = types.JoinMethod(serverInit.JoinMethod)
+	return joinResult, nil
+}
+
+// checkServerJoinMethod verifies that the join method selected by the server
+// matches the method the client explicitly requested, so that a misconfigured
+// or downgrading proxy cannot silently switch the client to a different
+// method. It always passes when the client left the method unspecified.
+func checkServerJoinMethod(requested types.JoinMethod, serverInit *messages.ServerInit) error {
+	if requested != types.JoinMethodUnspecified && serverInit.JoinMethod != string(requested) {
+		return trace.BadParameter("server selected join method %q but the client requested %q", serverInit.JoinMethod, requested)
+	}
+	return nil
 }
 
 func joinWithMethod(
@@ -0,0 +1,62 @@
+// Teleport
+// Copyright (C) 2025 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package joinclient
+
+import (
+	"testing"
+
+	"github.com/gravitational/trace"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/join/internal/messages"
+)
+
+func TestCheckServerJoinMethod(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		requested  types.JoinMethod
+		serverInit *messages.ServerInit
+		wantErr    bool
+	}{
+		{
+			desc:       "unspecified method accepts the server's choice",
+			requested:  types.JoinMethodUnspecified,
+			serverInit: &messages.ServerInit{JoinMethod: string(types.JoinMethodIAM)},
+		},
+		{
+			desc:       "matching method is accepted",
+			requested:  types.JoinMethodToken,
+			serverInit: &messages.ServerInit{JoinMethod: string(types.JoinMethodToken)},
+		},
+		{
+			desc:       "mismatched method is rejected",
+			requested:  types.JoinMethodIAM,
+			serverInit: &messages.ServerInit{JoinMethod: string(types.JoinMethodToken)},
+			wantErr:    true,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := checkServerJoinMethod(tc.requested, tc.serverInit)
+			if tc.wantErr {
+				require.True(t, trace.IsBadParameter(err), "expected BadParameter, got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -20,42 +20,151 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/gravitational/trace"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 
 	joinv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/join/v1"
 	"github.com/gravitational/teleport/lib/join/internal/messages"
 )
 
+// defaultKeepaliveInterval is a conservative default interval for client
+// keepalive pings on connections carrying join streams.
+const defaultKeepaliveInterval = 30 * time.Second
+
+// KeepaliveDialOption returns a gRPC dial option enabling transport-level
+// keepalive pings on the connection carrying the join stream. Challenge-based
+// join methods (TPM, cloud instance identity) can leave the stream idle for
+// several seconds while proofs are gathered, and intermediary proxies are
+// known to drop idle streams. The pings are handled by the HTTP/2 transport
+// on the server, no join protocol message is involved. A non-positive
+// interval falls back to [defaultKeepaliveInterval]. Dialers building the
+// connection a [Client] will run over, like the joinclient proxy path, pass
+// this alongside their own dial options.
+func KeepaliveDialOption(interval time.Duration) grpc.DialOption {
+	return grpc.WithKeepaliveParams(keepaliveParams(interval))
+}
+
+// keepaliveParams builds the client keepalive parameters for connections
+// carrying join streams, falling back to the conservative default interval
+// when none is configured.
+func keepaliveParams(interval time.Duration) keepalive.ClientParameters {
+	if interval <= 0 {
+		interval = defaultKeepaliveInterval
+	}
+	return keepalive.ClientParameters{
+		Time: interval,
+	}
+}
+
+// defaultRetryBackoff is the wait between join attempts when retries are
+// enabled and no backoff was configured.
+const defaultRetryBackoff = time.Second
+
 // Client wraps a gRPC client to provide a protocol-agnostic client for cluster
 // joining.
 type Client struct {
 	grpcClient joinv1.JoinServiceClient
+	// maxRetries is the number of times a failed join attempt is retried on
+	// top of the initial attempt. Zero disables retries.
+	maxRetries int
+	// retryBackoff is the wait between join attempts.
+	retryBackoff time.Duration
+	// deadline bounds each Join call overall, including all retries. Zero
+	// means no deadline beyond the caller's context.
+	deadline time.Duration
+}
+
+// ClientOption customizes a [Client].
+type ClientOption func(*Client)
+
+// WithMaxRetries makes Join retry failed attempts up to n more times when the
+// failure looks transient (Unavailable or DeadlineExceeded gRPC codes).
+// Permanent failures such as PermissionDenied are never retried.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// WithRetryBackoff sets the wait between join attempts. A non-positive
+// backoff falls back to [defaultRetryBackoff].
+func WithRetryBackoff(backoff time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryBackoff = backoff
+	}
+}
+
+// WithDeadline bounds each Join call overall, covering the initial attempt,
+// all retries and the backoff waits between them.
+func WithDeadline(deadline time.Duration) ClientOption {
+	return func(c *Client) {
+		c.deadline = deadline
+	}
 }
 
 // NewClient returns a new [Client] wrapping the plain gRPC client.
-func NewClient(grpcClient joinv1.JoinServiceClient) *Client {
-	return &Client{
-		grpcClient: grpcClient,
+func NewClient(grpcClient joinv1.JoinServiceClient, opts ...ClientOption) *Client {
+	c := &Client{
+		grpcClient:   grpcClient,
+		retryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retryBackoff <= 0 {
+		c.retryBackoff = defaultRetryBackoff
 	}
+	return c
 }
 
 // NewClientFromConn returns a new [Client] wrapping plain gRPC ClientConn.
-func NewClientFromConn(cc *grpc.ClientConn) *Client {
-	return &Client{
-		grpcClient: joinv1.NewJoinServiceClient(cc),
-	}
+func NewClientFromConn(cc *grpc.ClientConn, opts ...ClientOption) *Client {
+	return NewClient(joinv1.NewJoinServiceClient(cc), opts...)
 }
 
-// Join implements cluster joining for nodes and bots.
+// Join implements cluster joining for nodes and bots. When retries are
+// configured, attempts failing with a retryable gRPC code are repeated up to
+// the limit with the configured backoff in between; the configured deadline
+// bounds the whole call.
 func (c *Client) Join(ctx context.Context) (messages.ClientStream, error) {
-	ctx, cancel := context.WithCancelCause(ctx)
+	var cancelDeadline context.CancelFunc
+	if c.deadline > 0 {
+		ctx, cancelDeadline = context.WithTimeout(ctx, c.deadline)
+	}
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	cancel := cancelCause
+	if cancelDeadline != nil {
+		// The stream outlives this call, so the deadline context is
+		// released together with the stream's own context.
+		cancel = func(cause error) {
+			cancelCause(cause)
+			cancelDeadline()
+		}
+	}
 
-	grpcStream, err := c.grpcClient.Join(ctx)
-	if err != nil {
-		cancel(err)
-		return nil, trace.Wrap(err)
+	var grpcStream grpc.BidiStreamingClient[joinv1.JoinRequest, joinv1.JoinResponse]
+	var err error
+	for attempt := 0; ; attempt++ {
+		grpcStream, err = c.grpcClient.Join(ctx)
+		if err == nil {
+			break
+		}
+		if attempt >= c.maxRetries || !isRetryableJoinError(err) {
+			cancel(err)
+			return nil, trace.Wrap(err)
+		}
+		select {
+		case <-time.After(c.retryBackoff):
+		case <-ctx.Done():
+			err := context.Cause(ctx)
+			cancel(err)
+			return nil, trace.Wrap(err)
+		}
 	}
 
 	return &clientStream{
@@ -64,6 +173,19 @@ func (c *Client) Join(ctx context.Context) (messages.ClientStream, error) {
 	}, nil
 }
 
+// isRetryableJoinError reports whether a failed join attempt is worth
+// retrying. Only codes signaling a transient transport or server condition
+// qualify; anything the server decided on purpose, like PermissionDenied for
+// a bad token, fails fast.
+func isRetryableJoinError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
 // clientStream implements [messages.ClientStream]. Internally it converts
 // [messages.Request] and [messages.Response] type to/from gRPC messages and
 // translates Send/Recv calls to calls on the real gRPC client.
@@ -84,7 +206,7 @@ func (s *clientStream) Send(msg messages.Request) (err error) {
 	if err != nil {
 		return trace.Wrap(err)
 	}
-	if sendErr := s.grpcStream.Send(req); err != nil {
+	if sendErr := s.grpcStream.Send(req); sendErr != nil {
 		if errors.Is(sendErr, io.EOF) {
 			// From the docs on Send: if the error was generated by the client,
 			// the status is returned directly. Otherwise, io.EOF is returned,
@@ -102,7 +224,7 @@ func (s *clientStream) Send(msg messages.Request) (err error) {
 			}
 			return trace.Wrap(recvErr, "gRPC stream failed")
 		}
-		return trace.Wrap(err, "sending request to gRPC stream")
+		return trace.Wrap(sendErr, "sending request to gRPC stream")
 	}
 	return nil
 }
@@ -0,0 +1,245 @@
+// Teleport
+// Copyright (C) 2025 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package joinv1
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/gravitational/trace"
+	"github.com/stretchr/testify/require"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	joinv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/join/v1"
+	"github.com/gravitational/teleport/lib/join/internal/messages"
+)
+
+// TestNewClient verifies that both client constructors produce a usable
+// client with the underlying gRPC client populated.
+func TestNewClient(t *testing.T) {
+	grpcClient := joinv1.NewJoinServiceClient(nil)
+	require.NotNil(t, NewClient(grpcClient).grpcClient)
+	require.NotNil(t, NewClientFromConn(&grpc.ClientConn{}).grpcClient)
+}
+
+// TestKeepaliveParams verifies that the keepalive parameters behind
+// [KeepaliveDialOption] honor a configured interval and fall back to the
+// conservative default when none is given.
+func TestKeepaliveParams(t *testing.T) {
+	require.Equal(t, defaultKeepaliveInterval, keepaliveParams(0).Time)
+	require.Equal(t, defaultKeepaliveInterval, keepaliveParams(-time.Second).Time)
+	require.Equal(t, time.Minute, keepaliveParams(time.Minute).Time)
+}
+
+// fakeJoinServiceClient is a fake [joinv1.JoinServiceClient] whose Join fails
+// a configured number of times before succeeding, for exercising the retry
+// behavior of [Client.Join].
+type fakeJoinServiceClient struct {
+	joinv1.JoinServiceClient
+
+	failures int
+	code     codes.Code
+	calls    int
+}
+
+func (f *fakeJoinServiceClient) Join(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[joinv1.JoinRequest, joinv1.JoinResponse], error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, status.Error(f.code, "join attempt failed")
+	}
+	return &fakeGrpcStream{}, nil
+}
+
+// TestClientJoinRetries verifies that Join retries attempts failing with
+// retryable gRPC codes up to the configured limit, and fails fast on
+// permanent errors.
+func TestClientJoinRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		failures  int
+		code      codes.Code
+		opts      []ClientOption
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "no retries by default",
+			failures:  1,
+			code:      codes.Unavailable,
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "retries until success",
+			failures:  2,
+			code:      codes.Unavailable,
+			opts:      []ClientOption{WithMaxRetries(3), WithRetryBackoff(time.Millisecond)},
+			wantCalls: 3,
+		},
+		{
+			name:      "deadline exceeded is retryable",
+			failures:  1,
+			code:      codes.DeadlineExceeded,
+			opts:      []ClientOption{WithMaxRetries(1), WithRetryBackoff(time.Millisecond)},
+			wantCalls: 2,
+		},
+		{
+			name:      "gives up after the limit",
+			failures:  3,
+			code:      codes.Unavailable,
+			opts:      []ClientOption{WithMaxRetries(2), WithRetryBackoff(time.Millisecond)},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "permission denied fails fast",
+			failures:  3,
+			code:      codes.PermissionDenied,
+			opts:      []ClientOption{WithMaxRetries(3), WithRetryBackoff(time.Millisecond)},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeJoinServiceClient{failures: tc.failures, code: tc.code}
+			stream, err := NewClient(fake, tc.opts...).Join(context.Background())
+			if tc.wantErr {
+				require.Error(t, err)
+				require.Equal(t, tc.code, status.Code(trace.Unwrap(err)))
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, stream)
+			}
+			require.Equal(t, tc.wantCalls, fake.calls)
+		})
+	}
+}
+
+// TestClientJoinDeadline verifies that the configured deadline bounds the
+// whole Join call including the backoff waits between retries.
+func TestClientJoinDeadline(t *testing.T) {
+	fake := &fakeJoinServiceClient{failures: 100, code: codes.Unavailable}
+	client := NewClient(fake,
+		WithMaxRetries(100),
+		WithRetryBackoff(time.Hour),
+		WithDeadline(10*time.Millisecond),
+	)
+	_, err := client.Join(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 1, fake.calls)
+}
+
+// fakeGrpcStream is a minimal fake of the bidirectional gRPC join stream for
+// exercising the error paths of [clientStream] without a real connection.
+type fakeGrpcStream struct {
+	grpc.ClientStream
+
+	sendErr       error
+	recvResponses []*joinv1.JoinResponse
+	recvErr       error
+}
+
+func (f *fakeGrpcStream) Send(*joinv1.JoinRequest) error {
+	return f.sendErr
+}
+
+func (f *fakeGrpcStream) Recv() (*joinv1.JoinResponse, error) {
+	if len(f.recvResponses) > 0 {
+		resp := f.recvResponses[0]
+		f.recvResponses = f.recvResponses[1:]
+		return resp, nil
+	}
+	return nil, f.recvErr
+}
+
+func (f *fakeGrpcStream) CloseSend() error {
+	return nil
+}
+
+func newTestClientStream(fake *fakeGrpcStream) (*clientStream, *error) {
+	var cancelCause error
+	return &clientStream{
+		grpcStream: fake,
+		cancel: func(cause error) {
+			cancelCause = cause
+		},
+	}, &cancelCause
+}
+
+// TestClientStreamSendError verifies that errors returned by the underlying
+// gRPC Send surface to the caller instead of being dropped, and that they
+// cancel the stream context.
+func TestClientStreamSendError(t *testing.T) {
+	sendErr := trace.AccessDenied("token expired")
+	stream, cancelCause := newTestClientStream(&fakeGrpcStream{sendErr: sendErr})
+
+	err := stream.Send(&messages.ClientInit{})
+	require.ErrorIs(t, err, sendErr)
+	require.ErrorIs(t, *cancelCause, sendErr)
+}
+
+// TestClientStreamSendEOF verifies that when Send returns io.EOF the client
+// recovers the real RPC error from Recv, per the gRPC Send contract.
+func TestClientStreamSendEOF(t *testing.T) {
+	recvErr := trace.AccessDenied("unsupported join method")
+	stream, cancelCause := newTestClientStream(&fakeGrpcStream{
+		sendErr: io.EOF,
+		recvErr: recvErr,
+	})
+
+	err := stream.Send(&messages.ClientInit{})
+	require.ErrorIs(t, err, recvErr)
+	require.ErrorIs(t, *cancelCause, recvErr)
+}
+
+// TestClientStreamRecv verifies that Recv converts responses to messages,
+// passes io.EOF through unwrapped, and cancels the stream on real errors.
+func TestClientStreamRecv(t *testing.T) {
+	recvErr := trace.BadParameter("malformed response")
+	stream, cancelCause := newTestClientStream(&fakeGrpcStream{
+		recvResponses: []*joinv1.JoinResponse{{
+			Payload: &joinv1.JoinResponse_Init{Init: &joinv1.ServerInit{
+				JoinMethod: "token",
+			}},
+		}},
+		recvErr: recvErr,
+	})
+
+	msg, err := stream.Recv()
+	require.NoError(t, err)
+	serverInit, ok := msg.(*messages.ServerInit)
+	require.True(t, ok, "expected *messages.ServerInit, got %T", msg)
+	require.Equal(t, "token", serverInit.JoinMethod)
+
+	_, err = stream.Recv()
+	require.ErrorIs(t, err, recvErr)
+	require.ErrorIs(t, *cancelCause, recvErr)
+}
+
+// TestClientStreamRecvEOF verifies that a graceful end of stream is reported
+// as plain io.EOF and does not cancel the stream context.
+func TestClientStreamRecvEOF(t *testing.T) {
+	stream, cancelCause := newTestClientStream(&fakeGrpcStream{recvErr: io.EOF})
+
+	_, err := stream.Recv()
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, *cancelCause)
+}
@@ -17,14 +17,321 @@
 package joinv1
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
 	joinv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/join/v1"
+	"github.com/gravitational/teleport/lib/join/internal/messages"
 )
 
+// TestResponseToMessage tests that parsing a gRPC [joinv1.JoinResponse] into
+// a [messages.Response] does not trigger a panic on the client, and that
+// responses without a recognized payload are rejected.
+func TestResponseToMessage(t *testing.T) {
+	for _, tc := range []struct {
+		desc    string
+		resp    *joinv1.JoinResponse
+		wantErr bool
+	}{
+		{
+			desc:    "nil resp",
+			wantErr: true,
+		},
+		{
+			desc:    "nil payload",
+			resp:    &joinv1.JoinResponse{},
+			wantErr: true,
+		},
+		{
+			desc: "empty Init",
+			resp: &joinv1.JoinResponse{
+				Payload: &joinv1.JoinResponse_Init{},
+			},
+		},
+		{
+			desc: "empty Challenge",
+			resp: &joinv1.JoinResponse{
+				Payload: &joinv1.JoinResponse_Challenge{},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "empty Result",
+			resp: &joinv1.JoinResponse{
+				Payload: &joinv1.JoinResponse_Result{},
+			},
+			wantErr: true,
+		},
+		{
+			desc: "empty HostResult",
+			resp: &joinv1.JoinResponse{
+				Payload: &joinv1.JoinResponse_Result{
+					Result: &joinv1.Result{
+						Payload: &joinv1.Result_HostResult{},
+					},
+				},
+			},
+		},
+		{
+			desc: "empty BotResult",
+			resp: &joinv1.JoinResponse{
+				Payload: &joinv1.JoinResponse_Result{
+					Result: &joinv1.Result{
+						Payload: &joinv1.Result_BotResult{},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			// Put the response through a marshal/unmarshal to more closely
+			// model a response that actually came in over the network.
+			buf, err := proto.Marshal(tc.resp)
+			require.NoError(t, err)
+			var resp joinv1.JoinResponse
+			err = proto.Unmarshal(buf, &resp)
+			require.NoError(t, err)
+
+			require.NotPanics(t, func() {
+				_, err = responseToMessage(&resp)
+			})
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestRequestFromMessageRoundTrip verifies that the client-side request
+// converter populates the gRPC message from a [messages.Request] so that the
+// server-side converter recovers the original, covering the ClientInit proxy
+// parameters and the key, principal, and expiry fields of TokenInit.
+func TestRequestFromMessageRoundTrip(t *testing.T) {
+	expires := time.Date(2026, 8, 29, 12, 0, 0, 0, time.UTC)
+	joinMethod := "token"
+	for _, tc := range []struct {
+		desc string
+		msg  messages.Request
+	}{
+		{
+			desc: "ClientInit with proxy params",
+			msg: &messages.ClientInit{
+				JoinMethod:       &joinMethod,
+				TokenName:        "token-name",
+				SystemRole:       "Node",
+				ForwardedByProxy: true,
+				ProxySuppliedParams: &messages.ProxySuppliedParams{
+					RemoteAddr:    "127.0.0.1:3022",
+					ClientVersion: "19.0.0",
+				},
+			},
+		},
+		{
+			desc: "ClientInit without proxy params",
+			msg: &messages.ClientInit{
+				TokenName:  "token-name",
+				SystemRole: "Node",
+			},
+		},
+		{
+			desc: "TokenInit with host params",
+			msg: &messages.TokenInit{
+				ClientParams: messages.ClientParams{
+					HostParams: &messages.HostParams{
+						PublicKeys: messages.PublicKeys{
+							PublicTLSKey: []byte("tls-key"),
+							PublicSSHKey: []byte("ssh-key"),
+						},
+						HostName:             "node1",
+						AdditionalPrincipals: []string{"node1.example.com"},
+						DNSNames:             []string{"node1.internal"},
+					},
+				},
+				Secret: "secret",
+			},
+		},
+		{
+			desc: "EC2Init with signed identity document",
+			msg: &messages.EC2Init{
+				ClientParams: messages.ClientParams{
+					HostParams: &messages.HostParams{
+						PublicKeys: messages.PublicKeys{
+							PublicTLSKey: []byte("tls-key"),
+							PublicSSHKey: []byte("ssh-key"),
+						},
+						HostName: "ec2-node",
+					},
+				},
+				Document: []byte("signed-iid"),
+			},
+		},
+		{
+			// All OIDC-based join methods (github, gitlab, spacelift,
+			// bitbucket, circleci, kubernetes, ...) share these generic
+			// messages: the conversion has no per-method branching, so one
+			// entry per ClientParams shape is enough coverage here.
+			desc: "OIDCInit with ID token",
+			msg: &messages.OIDCInit{
+				ClientParams: messages.ClientParams{
+					BotParams: &messages.BotParams{
+						PublicKeys: messages.PublicKeys{
+							PublicTLSKey: []byte("tls-key"),
+							PublicSSHKey: []byte("ssh-key"),
+						},
+					},
+				},
+				IDToken: []byte("workflow-id-token"),
+			},
+		},
+		{
+			// GitLab runners join with the generic OIDC messages: the CI job
+			// JWT travels in OIDCInit and the server routes it by the gitlab
+			// join method from the provision token.
+			desc: "OIDCInit with GitLab CI job token",
+			msg: &messages.OIDCInit{
+				ClientParams: messages.ClientParams{
+					HostParams: &messages.HostParams{
+						PublicKeys: messages.PublicKeys{
+							PublicTLSKey: []byte("tls-key"),
+							PublicSSHKey: []byte("ssh-key"),
+						},
+						HostName: "gitlab-runner",
+					},
+				},
+				IDToken: []byte("gitlab-ci-job-jwt"),
+			},
+		},
+		{
+			desc: "TokenInit with bot params and expiry",
+			msg: &messages.TokenInit{
+				ClientParams: messages.ClientParams{
+					BotParams: &messages.BotParams{
+						PublicKeys: messages.PublicKeys{
+							PublicTLSKey: []byte("tls-key"),
+							PublicSSHKey: []byte("ssh-key"),
+						},
+						Expires: &expires,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			req, err := requestFromMessage(tc.msg)
+			require.NoError(t, err)
+			roundTripped, err := requestToMessage(req)
+			require.NoError(t, err)
+			require.Equal(t, tc.msg, roundTripped)
+		})
+	}
+}
+
+// TestTPMMessagesRoundTrip verifies that the TPM challenge message pair
+// survives conversion to and from the gRPC representation, and that a TPMInit
+// without exactly one endorsement credential is rejected.
+func TestTPMMessagesRoundTrip(t *testing.T) {
+	tpmInit := &messages.TPMInit{
+		ClientParams: messages.ClientParams{
+			HostParams: &messages.HostParams{
+				PublicKeys: messages.PublicKeys{
+					PublicTLSKey: []byte("tls-key"),
+					PublicSSHKey: []byte("ssh-key"),
+				},
+				HostName: "tpm-node",
+			},
+		},
+		Public:            []byte("tpmt-public"),
+		CreateData:        []byte("create-data"),
+		CreateAttestation: []byte("create-attestation"),
+		CreateSignature:   []byte("create-signature"),
+		EKCert:            []byte("ek-cert"),
+	}
+	req, err := requestFromMessage(tpmInit)
+	require.NoError(t, err)
+	roundTripped, err := requestToMessage(req)
+	require.NoError(t, err)
+	require.Equal(t, tpmInit, roundTripped)
+
+	// A TPMInit without exactly one of EKCert and EKKey must be rejected.
+	tpmInit.EKCert = nil
+	_, err = requestFromMessage(tpmInit)
+	require.Error(t, err)
+
+	solution := &messages.TPMSolution{Solution: []byte("activated-credential")}
+	req, err = requestFromMessage(solution)
+	require.NoError(t, err)
+	roundTripped, err = requestToMessage(req)
+	require.NoError(t, err)
+	require.Equal(t, solution, roundTripped)
+
+	credential := &messages.TPMEncryptedCredential{
+		CredentialBlob: []byte("credential-blob"),
+		Secret:         []byte("secret"),
+	}
+	resp, err := responseFromMessage(credential)
+	require.NoError(t, err)
+	roundTrippedResp, err := responseToMessage(resp)
+	require.NoError(t, err)
+	require.Equal(t, credential, roundTrippedResp)
+}
+
+// TestResultRoundTrip verifies that host and bot join results survive
+// conversion to and from the gRPC representation, including the host ID and
+// the bound keypair result. Note that bot instance identity is carried inside
+// the issued certificates rather than as a separate result field.
+func TestResultRoundTrip(t *testing.T) {
+	certs := messages.Certificates{
+		TLSCert:    []byte("tls-cert"),
+		TLSCACerts: [][]byte{[]byte("tls-ca")},
+		SSHCert:    []byte("ssh-cert"),
+		SSHCAKeys:  [][]byte{[]byte("ssh-ca")},
+	}
+	for _, msg := range []messages.Response{
+		&messages.HostResult{
+			Certificates: certs,
+			HostID:       "6e4ac4a5-c54b-4518-b1e3-d859a8f31b68",
+		},
+		&messages.BotResult{
+			Certificates: certs,
+			BoundKeypairResult: &messages.BoundKeypairResult{
+				JoinState: []byte("join-state"),
+				PublicKey: []byte("public-key"),
+			},
+		},
+	} {
+		t.Run(fmt.Sprintf("%T", msg), func(t *testing.T) {
+			resp, err := responseFromMessage(msg)
+			require.NoError(t, err)
+			roundTripped, err := responseToMessage(resp)
+			require.NoError(t, err)
+			require.Equal(t, msg, roundTripped)
+		})
+	}
+}
+
+// TestCertificatesRoundTrip verifies that the TLS and SSH certificate fields
+// stay distinct when converting certificates to and from the gRPC
+// representation, guarding against crossed field mappings that would hand a
+// joining node its TLS cert where the SSH cert belongs.
+func TestCertificatesRoundTrip(t *testing.T) {
+	msg := messages.Certificates{
+		TLSCert:    []byte("tls-cert"),
+		TLSCACerts: [][]byte{[]byte("tls-ca")},
+		SSHCert:    []byte("ssh-cert"),
+		SSHCAKeys:  [][]byte{[]byte("ssh-ca")},
+	}
+	converted := certificatesFromMessage(&msg)
+	require.Equal(t, []byte("tls-cert"), converted.GetTlsCert())
+	require.Equal(t, []byte("ssh-cert"), converted.GetSshCert())
+	require.Equal(t, msg, certificatesToMessage(converted))
+}
+
 // TestRequestToMessage tests that parsing a gRPC [joinv1.JoinRequest] into a
 // [messages.Request] does not trigger a panic on the server. These gRPC
 // requests come in over the network from untrusted clients.
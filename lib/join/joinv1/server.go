@@ -88,6 +88,9 @@ func (s *joinServer) Join(grpcStream grpc.BidiStreamingServer[joinv1.JoinRequest
 		ctx:        ctx,
 		diag:       diag,
 	}
+	// The error channel is buffered so that the goroutine running the join
+	// can always deliver its result and exit, even when the deadline fires
+	// first and this handler has already returned.
 	errCh := make(chan error, 1)
 	go func() {
 		errCh <- trace.Wrap(s.server.Join(messageStream))
@@ -0,0 +1,227 @@
+// Teleport
+// Copyright (C) 2025 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package joinv1
+
+import (
+	"context"
+	"net"
+	"testing"
+	"testing/synctest"
+
+	"github.com/gravitational/trace"
+	"github.com/stretchr/testify/require"
+	grpc "google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	joinv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/join/v1"
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/join/internal/messages"
+)
+
+// stuckMessageServer models a join attempt that never completes: it blocks
+// until the stream context is canceled.
+type stuckMessageServer struct{}
+
+func (stuckMessageServer) Join(stream messages.ServerStream) error {
+	<-stream.Context().Done()
+	return trace.Wrap(context.Cause(stream.Context()))
+}
+
+// fakeBidiServer is a minimal fake of the server side of the bidirectional
+// join stream carrying only a context.
+type fakeBidiServer struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+func (f *fakeBidiServer) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeBidiServer) Send(*joinv1.JoinResponse) error {
+	return nil
+}
+
+func (f *fakeBidiServer) Recv() (*joinv1.JoinRequest, error) {
+	<-f.ctx.Done()
+	return nil, context.Cause(f.ctx)
+}
+
+// fakeRegistrar captures the implementation registered with a gRPC server.
+type fakeRegistrar struct {
+	impl any
+}
+
+func (f *fakeRegistrar) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	f.impl = impl
+}
+
+// TestRegisterJoinServiceServer verifies that the registered gRPC service
+// carries the provided message server, so incoming Join calls are delegated
+// rather than hitting a nil implementation.
+func TestRegisterJoinServiceServer(t *testing.T) {
+	var reg fakeRegistrar
+	RegisterJoinServiceServer(&reg, stuckMessageServer{})
+	srv, ok := reg.impl.(*joinServer)
+	require.True(t, ok, "expected *joinServer, got %T", reg.impl)
+	require.NotNil(t, srv.server)
+}
+
+// completedMessageServer models a join attempt that finishes immediately.
+type completedMessageServer struct{}
+
+func (completedMessageServer) Join(stream messages.ServerStream) error {
+	return nil
+}
+
+// TestJoinNormalCompletion verifies that a join completing before the server
+// deadline returns promptly and leaves no goroutine behind. The synctest
+// bubble fails the test if any goroutine started inside it outlives it.
+func TestJoinNormalCompletion(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := peer.NewContext(t.Context(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 3022},
+		})
+		s := &joinServer{server: completedMessageServer{}}
+
+		require.NoError(t, s.Join(&fakeBidiServer{ctx: ctx}))
+	})
+}
+
+// multiRoundMessageServer models a join method that needs two challenge
+// exchanges before issuing the terminal result, as future attestation flows
+// may. Each round sends a challenge and waits for the matching solution.
+type multiRoundMessageServer struct{}
+
+func (multiRoundMessageServer) Join(stream messages.ServerStream) error {
+	if _, err := messages.RecvRequest[*messages.ClientInit](stream); err != nil {
+		return trace.Wrap(err)
+	}
+	if err := stream.Send(&messages.ServerInit{JoinMethod: string(types.JoinMethodOracle)}); err != nil {
+		return trace.Wrap(err)
+	}
+	for _, challenge := range []string{"challenge-1", "challenge-2"} {
+		if err := stream.Send(&messages.OracleChallenge{Challenge: challenge}); err != nil {
+			return trace.Wrap(err)
+		}
+		if _, err := messages.RecvRequest[*messages.OracleChallengeSolution](stream); err != nil {
+			return trace.Wrap(err)
+		}
+	}
+	return trace.Wrap(stream.Send(&messages.HostResult{HostID: "host-id"}))
+}
+
+// pipeBidiServer is a fake of the server side of the bidirectional join
+// stream whose Send and Recv are backed by channels, letting a test act as
+// the remote client.
+type pipeBidiServer struct {
+	grpc.ServerStream
+
+	ctx    context.Context
+	reqCh  chan *joinv1.JoinRequest
+	respCh chan *joinv1.JoinResponse
+}
+
+func (p *pipeBidiServer) Context() context.Context {
+	return p.ctx
+}
+
+func (p *pipeBidiServer) Send(resp *joinv1.JoinResponse) error {
+	select {
+	case p.respCh <- resp:
+		return nil
+	case <-p.ctx.Done():
+		return context.Cause(p.ctx)
+	}
+}
+
+func (p *pipeBidiServer) Recv() (*joinv1.JoinRequest, error) {
+	select {
+	case req := <-p.reqCh:
+		return req, nil
+	case <-p.ctx.Done():
+		return nil, context.Cause(p.ctx)
+	}
+}
+
+// TestJoinMultipleChallengeRounds verifies that nothing in the gRPC layer or
+// the message converters limits a join method to a single challenge
+// exchange: the service can send any number of challenges and receive the
+// matching solutions before the terminal result.
+func TestJoinMultipleChallengeRounds(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := peer.NewContext(t.Context(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 3022},
+		})
+		s := &joinServer{server: multiRoundMessageServer{}}
+		stream := &pipeBidiServer{
+			ctx:    ctx,
+			reqCh:  make(chan *joinv1.JoinRequest),
+			respCh: make(chan *joinv1.JoinResponse),
+		}
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- s.Join(stream)
+		}()
+
+		send := func(msg messages.Request) {
+			req, err := requestFromMessage(msg)
+			require.NoError(t, err)
+			stream.reqCh <- req
+		}
+		recv := func() messages.Response {
+			msg, err := responseToMessage(<-stream.respCh)
+			require.NoError(t, err)
+			return msg
+		}
+
+		send(&messages.ClientInit{TokenName: "token", SystemRole: "Node"})
+		serverInit, ok := recv().(*messages.ServerInit)
+		require.True(t, ok, "expected ServerInit")
+		require.Equal(t, string(types.JoinMethodOracle), serverInit.JoinMethod)
+
+		for _, wantChallenge := range []string{"challenge-1", "challenge-2"} {
+			challenge, ok := recv().(*messages.OracleChallenge)
+			require.True(t, ok, "expected OracleChallenge")
+			require.Equal(t, wantChallenge, challenge.Challenge)
+			send(&messages.OracleChallengeSolution{
+				Signature: []byte("signed " + challenge.Challenge),
+			})
+		}
+
+		result, ok := recv().(*messages.HostResult)
+		require.True(t, ok, "expected HostResult")
+		require.Equal(t, "host-id", result.HostID)
+		require.NoError(t, <-errCh)
+	})
+}
+
+// TestJoinRequestTimeout verifies that the server tears down a join stream
+// that does not complete within the join request timeout, so a stuck client
+// cannot hold a stream open indefinitely.
+func TestJoinRequestTimeout(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		ctx := peer.NewContext(t.Context(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 3022},
+		})
+		s := &joinServer{server: stuckMessageServer{}}
+
+		err := s.Join(&fakeBidiServer{ctx: ctx})
+		require.True(t, trace.IsLimitExceeded(err), "expected LimitExceeded, got %v", err)
+	})
+}
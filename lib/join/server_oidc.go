@@ -51,6 +51,9 @@ func (s *Server) handleOIDCJoin(
 	if err != nil {
 		return nil, trace.Wrap(err)
 	}
+	if err := oidcInit.Check(); err != nil {
+		return nil, trace.Wrap(err)
+	}
 	// Set any diagnostic info from the ClientParams.
 	setDiagnosticClientParams(stream.Diagnostic(), &oidcInit.ClientParams)
 
@@ -76,6 +76,7 @@ import (
 	"github.com/gravitational/teleport/api/types/installers"
 	"github.com/gravitational/teleport/api/utils/clientutils"
 	"github.com/gravitational/teleport/api/utils/keys"
+	"github.com/gravitational/teleport/api/utils/retryutils"
 	apisshutils "github.com/gravitational/teleport/api/utils/sshutils"
 	"github.com/gravitational/teleport/entitlements"
 	"github.com/gravitational/teleport/lib/auth"
@@ -138,6 +139,10 @@ const (
 	// DefaultFeatureWatchInterval is the default time in which the feature watcher
 	// should ping the auth server to check for updated features
 	DefaultFeatureWatchInterval = time.Minute * 5
+	// DefaultFeatureWatchPingTimeout is the default timeout applied to each
+	// ping the feature watcher sends to the auth server, so a hung
+	// connection can't block a tick indefinitely
+	DefaultFeatureWatchPingTimeout = 30 * time.Second
 	// findEndpointCacheTTL is the cache TTL for the find endpoint generic answer.
 	// This cache is here to protect against accidental or intentional DDoS, the TTL must be low to quickly reflect
 	// cluster configuration changes.
@@ -173,6 +178,10 @@ type Handler struct {
 	// clusterFeatures contain flags for supported and unsupported features.
 	clusterFeatures proto.Features
 
+	// lastFeatureSync is the time of the most recent successful feature
+	// fetch from the auth server, guarded by the handler mutex.
+	lastFeatureSync time.Time
+
 	// nodeWatcher is a services.NodeWatcher used by Assist to lookup nodes from
 	// the proxy's cache and get nodes in real time.
 	nodeWatcher *services.GenericWatcher[types.Server, readonly.Server]
@@ -333,6 +342,21 @@ type Config struct {
 	// to fetch new cluster features
 	FeatureWatchInterval time.Duration
 
+	// FeatureWatchJitter randomizes each feature watch interval so that
+	// proxies restarted together don't all ping the auth server on the same
+	// boundary. Defaults to a jitter of up to ±10% of the interval.
+	FeatureWatchJitter retryutils.Jitter
+
+	// FeatureWatchPingTimeout bounds each ping the feature watcher sends to
+	// the auth server. Defaults to DefaultFeatureWatchPingTimeout.
+	FeatureWatchPingTimeout time.Duration
+
+	// OnFeaturesChanged is an optional hook invoked by the feature watcher
+	// when the newly fetched features differ from the currently applied
+	// ones, e.g. to invalidate caches keyed on entitlements. It is called
+	// outside the handler lock with the previous and new features.
+	OnFeaturesChanged func(old, new proto.Features)
+
 	// DatabaseREPLRegistry is used for retrieving database REPL.
 	DatabaseREPLRegistry dbrepl.REPLRegistry
 }
@@ -357,6 +381,11 @@ func (c *Config) SetDefaults() {
 	c.ProxyGroupID = cmp.Or(c.ProxyGroupID, os.Getenv("TELEPORT_UNSTABLE_PROXYGROUP_ID"))
 
 	c.FeatureWatchInterval = cmp.Or(c.FeatureWatchInterval, DefaultFeatureWatchInterval)
+	c.FeatureWatchPingTimeout = cmp.Or(c.FeatureWatchPingTimeout, DefaultFeatureWatchPingTimeout)
+
+	if c.FeatureWatchJitter == nil {
+		c.FeatureWatchJitter = featureWatchJitter
+	}
 }
 
 type APIHandler struct {
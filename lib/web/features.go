@@ -19,8 +19,34 @@
 package web
 
 import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/gravitational/trace"
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/gravitational/teleport/api/client/proto"
+	apiutils "github.com/gravitational/teleport/api/utils"
 	"github.com/gravitational/teleport/entitlements"
+	"github.com/gravitational/teleport/lib/observability/metrics"
+)
+
+var (
+	featureWatchUpdates = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "teleport_web_feature_watch_updates_total",
+			Help: "Number of feature watcher syncs that applied changed cluster features",
+		},
+	)
+	featureWatchErrors = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "teleport_web_feature_watch_errors_total",
+			Help: "Number of failed feature watcher pings to the auth server",
+		},
+	)
 )
 
 // SetClusterFeatures sets the flags for supported and unsupported features.
@@ -42,32 +68,163 @@ func (h *Handler) GetClusterFeatures() proto.Features {
 	return h.clusterFeatures
 }
 
+// CurrentFeatures returns a defensive deep copy of the features most recently
+// applied by the feature watcher. Unlike GetClusterFeatures, the returned
+// value shares no reference fields (such as the entitlements map) with the
+// handler, so callers may hold on to it or mutate it while the watcher keeps
+// updating the cached features.
+func (h *Handler) CurrentFeatures() proto.Features {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+
+	return *apiutils.CloneProtoMsg(&h.clusterFeatures)
+}
+
+// maxFeatureWatchBackoffFactor caps the exponential backoff of the feature
+// watcher while the auth server is unreachable, as a multiple of the
+// configured watch interval.
+const maxFeatureWatchBackoffFactor = 8
+
 // startFeatureWatcher periodically pings the auth server and updates `clusterFeatures`.
 // Must be called only once per `handler`, otherwise it may close an already closed channel
 // which will cause a panic.
-// The watcher doesn't ping the auth server immediately upon start because features are
-// already set by the config object in `NewHandler`.
+// The watcher syncs features once immediately on start, so that a restarted proxy
+// doesn't keep serving the stale features from the config object in `NewHandler`
+// for a whole interval, and then keeps syncing on the configured interval. While
+// the auth server is unreachable the watcher backs off exponentially, up to
+// [maxFeatureWatchBackoffFactor] times the interval, and resets to the base
+// interval on the next successful sync.
 func (h *Handler) startFeatureWatcher() {
 	ctx := h.cfg.Context
-	ticker := h.clock.NewTicker(h.cfg.FeatureWatchInterval)
-	h.logger.InfoContext(ctx, "Proxy handler features watcher has started", "interval", h.cfg.FeatureWatchInterval)
+	// Registration is idempotent, so several handlers in one process can
+	// each start their own watcher.
+	if err := metrics.RegisterPrometheusCollectors(featureWatchUpdates, featureWatchErrors); err != nil {
+		h.logger.WarnContext(ctx, "Failed to register feature watcher metrics", "error", err)
+	}
+	jitter := h.cfg.FeatureWatchJitter
+	if jitter == nil {
+		jitter = featureWatchJitter
+	}
+	interval := h.cfg.FeatureWatchInterval
+	timer := h.clock.NewTimer(jitter(interval))
+	h.logger.InfoContext(ctx, "Proxy handler features watcher has started", "interval", interval)
 
-	defer ticker.Stop()
+	defer timer.Stop()
+	delay := interval
+	if err := h.fetchClusterFeatures(ctx); err != nil {
+		h.logger.ErrorContext(ctx, "Auth server ping failed", "error", err)
+	}
 	for {
 		select {
-		case <-ticker.Chan():
-			h.logger.InfoContext(ctx, "Pinging auth server for features")
-			pingResponse, err := h.GetProxyClient().Ping(ctx)
-			if err != nil {
-				h.logger.ErrorContext(ctx, "Auth server ping failed", "error", err)
-				continue
+		case <-timer.Chan():
+			if err := h.fetchClusterFeatures(ctx); err != nil {
+				// Log loudly only when entering the backoff; follow-up
+				// failures are expected until auth recovers.
+				if delay == interval {
+					h.logger.ErrorContext(ctx, "Auth server ping failed, backing off", "error", err)
+				} else {
+					h.logger.DebugContext(ctx, "Auth server ping failed while backing off", "error", err, "delay", delay)
+				}
+				delay = min(delay*2, maxFeatureWatchBackoffFactor*interval)
+			} else {
+				delay = interval
 			}
-
-			h.SetClusterFeatures(*pingResponse.ServerFeatures)
-			h.logger.InfoContext(ctx, "Done updating proxy features", "features", pingResponse.ServerFeatures)
+			timer.Reset(jitter(delay))
 		case <-ctx.Done():
 			h.logger.InfoContext(ctx, "Feature service has stopped")
 			return
 		}
 	}
 }
+
+// featureWatchJitter is the default feature watch jitter: it randomizes the
+// interval by up to ±10% so that proxies restarted together spread their
+// pings to the auth server across the interval boundary.
+func featureWatchJitter(d time.Duration) time.Duration {
+	frac := d / 10
+	if frac < 1 {
+		return d
+	}
+	return d - frac + rand.N(2*frac)
+}
+
+// fetchClusterFeatures pings the auth server and caches the features it
+// advertises. A failed ping leaves the cached features intact.
+func (h *Handler) fetchClusterFeatures(ctx context.Context) (err error) {
+	// A panic while converting or applying the fetched features must not kill
+	// the watcher goroutine, or features would freeze until the proxy
+	// restarts. Recover and surface it as a failed sync so the watcher simply
+	// retries on the next tick.
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.ErrorContext(ctx, "Recovered from panic while applying cluster features", "panic", r)
+			err = trace.Errorf("panic while applying cluster features: %v", r)
+		}
+	}()
+	h.logger.InfoContext(ctx, "Pinging auth server for features")
+	pingCtx := ctx
+	if timeout := h.cfg.FeatureWatchPingTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		pingCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	pingResponse, err := h.GetProxyClient().Ping(pingCtx)
+	if err != nil {
+		featureWatchErrors.Inc()
+		return trace.Wrap(err)
+	}
+
+	old := h.GetClusterFeatures()
+	h.SetClusterFeatures(*pingResponse.ServerFeatures)
+	h.Mutex.Lock()
+	h.lastFeatureSync = h.clock.Now()
+	h.Mutex.Unlock()
+	h.logger.InfoContext(ctx, "Done updating proxy features", "features", pingResponse.ServerFeatures)
+
+	if updated := h.GetClusterFeatures(); !gogoproto.Equal(&old, &updated) {
+		featureWatchUpdates.Inc()
+		if diff := entitlementDiff(old.GetEntitlements(), updated.GetEntitlements()); len(diff) > 0 {
+			h.logger.InfoContext(ctx, "Cluster entitlements changed", "changes", diff)
+		}
+		// The hook runs outside the handler lock so it can safely call back
+		// into the handler, and only when the applied features actually
+		// changed.
+		if h.cfg.OnFeaturesChanged != nil {
+			h.cfg.OnFeaturesChanged(old, updated)
+		}
+	}
+	return nil
+}
+
+// entitlementDiff describes every entitlement whose enabled state differs
+// between the two sets, in the deterministic order of
+// [entitlements.AllEntitlements].
+func entitlementDiff(old, updated map[string]*proto.EntitlementInfo) []string {
+	var diff []string
+	for _, kind := range entitlements.AllEntitlements {
+		oldEnabled := old[string(kind)].GetEnabled()
+		newEnabled := updated[string(kind)].GetEnabled()
+		if oldEnabled != newEnabled {
+			diff = append(diff, fmt.Sprintf("%s: enabled %t -> %t", kind, oldEnabled, newEnabled))
+		}
+	}
+	return diff
+}
+
+// RefreshFeatures forces one synchronous feature fetch-and-apply cycle
+// outside the background watcher's schedule, e.g. to pick up an entitlement
+// flipped server-side without waiting for the next interval. It is safe to
+// call while the watcher is running and returns an error if the ping fails.
+func (h *Handler) RefreshFeatures(ctx context.Context) error {
+	return trace.Wrap(h.fetchClusterFeatures(ctx))
+}
+
+// LastFeatureSync returns the time of the most recent successful feature
+// fetch from the auth server, or the zero time if none has completed yet.
+// It lets health checks detect a feature watcher that has silently stalled.
+func (h *Handler) LastFeatureSync() time.Time {
+	h.Mutex.Lock()
+	defer h.Mutex.Unlock()
+
+	return h.lastFeatureSync
+}
@@ -26,6 +26,7 @@ import (
 	"testing/synctest"
 	"time"
 
+	"github.com/gravitational/trace"
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
 
@@ -43,13 +44,27 @@ type mockedFeatureGetter struct {
 
 	mu       sync.Mutex
 	features proto.Features
+	err      error
+	block    bool
+	pings    int
 }
 
 func (m *mockedFeatureGetter) Ping(ctx context.Context) (proto.PingResponse, error) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.pings++
+	block := m.block
+	err := m.err
+	features := utils.CloneProtoMsg(&m.features)
+	m.mu.Unlock()
+	if block {
+		<-ctx.Done()
+		return proto.PingResponse{}, trace.Wrap(ctx.Err())
+	}
+	if err != nil {
+		return proto.PingResponse{}, err
+	}
 	return proto.PingResponse{
-		ServerFeatures: utils.CloneProtoMsg(&m.features),
+		ServerFeatures: features,
 	}, nil
 }
 
@@ -59,7 +74,111 @@ func (m *mockedFeatureGetter) setFeatures(f proto.Features) {
 	m.features = f
 }
 
-func TestFeaturesWatcher(t *testing.T) {
+func (m *mockedFeatureGetter) setBlock(block bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.block = block
+}
+
+func (m *mockedFeatureGetter) setError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+}
+
+func (m *mockedFeatureGetter) pingCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pings
+}
+
+func TestCurrentFeatures(t *testing.T) {
+	handler := &Handler{
+		clusterFeatures: proto.Features{},
+	}
+	features := proto.Features{
+		Kubernetes: true,
+		Entitlements: map[string]*proto.EntitlementInfo{
+			string(entitlements.AccessLists): {Enabled: true},
+		},
+		AccessRequests: &proto.AccessRequestsFeature{},
+	}
+	handler.SetClusterFeatures(features)
+
+	current := handler.CurrentFeatures()
+	require.Equal(t, handler.GetClusterFeatures(), current)
+
+	// mutating the returned clone must not leak into the handler's cache
+	current.Entitlements[string(entitlements.AccessLists)].Enabled = false
+	require.True(t, handler.GetClusterFeatures().Entitlements[string(entitlements.AccessLists)].Enabled)
+}
+
+func TestRefreshFeatures(t *testing.T) {
+	mockClient := &mockedFeatureGetter{features: proto.Features{
+		Kubernetes:     true,
+		Entitlements:   map[string]*proto.EntitlementInfo{},
+		AccessRequests: &proto.AccessRequestsFeature{},
+	}}
+
+	handler := &Handler{
+		cfg: Config{
+			ProxyClient: mockClient,
+		},
+		clock:           clockwork.NewRealClock(),
+		clusterFeatures: proto.Features{},
+		logger:          slog.Default().With(teleport.ComponentKey, teleport.ComponentWeb),
+	}
+
+	require.NoError(t, handler.RefreshFeatures(t.Context()))
+
+	features := proto.Features{
+		Kubernetes:     true,
+		Entitlements:   map[string]*proto.EntitlementInfo{},
+		AccessRequests: &proto.AccessRequestsFeature{},
+	}
+	entitlements.BackfillFeatures(&features)
+	expected := utils.CloneProtoMsg(&features)
+	require.Equal(t, *expected, handler.GetClusterFeatures())
+
+	// a refresh picks up changed features without waiting for any interval
+	features = proto.Features{
+		Kubernetes:     false,
+		Entitlements:   map[string]*proto.EntitlementInfo{},
+		AccessRequests: &proto.AccessRequestsFeature{},
+	}
+	mockClient.setFeatures(features)
+	require.NoError(t, handler.RefreshFeatures(t.Context()))
+
+	entitlements.BackfillFeatures(&features)
+	expected = utils.CloneProtoMsg(&features)
+	require.Equal(t, *expected, handler.GetClusterFeatures())
+
+	// a failed ping surfaces the error and leaves the cache untouched
+	mockClient.setError(trace.ConnectionProblem(nil, "auth is down"))
+	require.Error(t, handler.RefreshFeatures(t.Context()))
+	require.Equal(t, *expected, handler.GetClusterFeatures())
+}
+
+func TestEntitlementDiff(t *testing.T) {
+	old := map[string]*proto.EntitlementInfo{
+		string(entitlements.AccessLists):      {Enabled: true},
+		string(entitlements.AccessMonitoring): {Enabled: false},
+	}
+	updated := map[string]*proto.EntitlementInfo{
+		string(entitlements.AccessLists):      {Enabled: true},
+		string(entitlements.AccessMonitoring): {Enabled: true},
+		string(entitlements.App):              {Enabled: true},
+	}
+	require.Equal(t, []string{
+		"AccessMonitoring: enabled false -> true",
+		"App: enabled false -> true",
+	}, entitlementDiff(old, updated))
+
+	// identical sets produce no diff
+	require.Empty(t, entitlementDiff(updated, updated))
+}
+
+func TestFeaturesChangedCallback(t *testing.T) {
 	synctest.Test(t, func(t *testing.T) {
 		mockClient := &mockedFeatureGetter{features: proto.Features{
 			Kubernetes:     true,
@@ -68,12 +187,21 @@ func TestFeaturesWatcher(t *testing.T) {
 		}}
 
 		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
 
+		var mu sync.Mutex
+		var changes []proto.Features
 		handler := &Handler{
 			cfg: Config{
 				FeatureWatchInterval: 100 * time.Millisecond,
+				FeatureWatchJitter:   func(d time.Duration) time.Duration { return d },
 				ProxyClient:          mockClient,
 				Context:              ctx,
+				OnFeaturesChanged: func(old, new proto.Features) {
+					mu.Lock()
+					defer mu.Unlock()
+					changes = append(changes, new)
+				},
 			},
 			clock:           clockwork.NewRealClock(),
 			clusterFeatures: proto.Features{},
@@ -83,16 +211,192 @@ func TestFeaturesWatcher(t *testing.T) {
 		go handler.startFeatureWatcher()
 		synctest.Wait()
 
-		// before running the watcher, features should match the value passed to the handler
-		require.Equal(t, proto.Features{}, handler.GetClusterFeatures())
+		// the initial sync changes the features from their zero value
+		mu.Lock()
+		require.Len(t, changes, 1)
+		require.True(t, changes[0].Kubernetes)
+		mu.Unlock()
 
-		// advance and wait. once this returns the first feature update will have completed
-		// and the updater will be blocked prior to the second update.
+		// a tick that fetches identical features must not fire the hook
 		time.Sleep(handler.cfg.FeatureWatchInterval)
 		synctest.Wait()
+		mu.Lock()
+		require.Len(t, changes, 1)
+		mu.Unlock()
+
+		// flipping an entitlement fires the hook again
+		features := proto.Features{
+			Kubernetes:     false,
+			Entitlements:   map[string]*proto.EntitlementInfo{},
+			AccessRequests: &proto.AccessRequestsFeature{},
+		}
+		mockClient.setFeatures(features)
+
+		time.Sleep(handler.cfg.FeatureWatchInterval)
+		synctest.Wait()
+		mu.Lock()
+		require.Len(t, changes, 2)
+		require.False(t, changes[1].Kubernetes)
+		mu.Unlock()
+	})
+}
+
+func TestFeaturesWatcherPingTimeout(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		mockClient := &mockedFeatureGetter{features: proto.Features{
+			Kubernetes:     true,
+			Entitlements:   map[string]*proto.EntitlementInfo{},
+			AccessRequests: &proto.AccessRequestsFeature{},
+		}}
+		mockClient.setBlock(true)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		interval := 100 * time.Millisecond
+		timeout := 10 * time.Millisecond
+		handler := &Handler{
+			cfg: Config{
+				FeatureWatchInterval:    interval,
+				FeatureWatchJitter:      func(d time.Duration) time.Duration { return d },
+				FeatureWatchPingTimeout: timeout,
+				ProxyClient:             mockClient,
+				Context:                 ctx,
+			},
+			clock:           clockwork.NewRealClock(),
+			clusterFeatures: proto.Features{},
+			logger:          slog.Default().With(teleport.ComponentKey, teleport.ComponentWeb),
+		}
+
+		go handler.startFeatureWatcher()
+
+		// the blocked initial ping is abandoned once the timeout expires and
+		// the cached features stay untouched
+		time.Sleep(timeout)
+		synctest.Wait()
+		require.Equal(t, 1, mockClient.pingCount())
+		require.Equal(t, proto.Features{}, handler.GetClusterFeatures())
+
+		// once the client stops hanging, the next tick succeeds
+		mockClient.setBlock(false)
+		time.Sleep(interval - timeout)
+		synctest.Wait()
+
+		features := proto.Features{
+			Kubernetes:     true,
+			Entitlements:   map[string]*proto.EntitlementInfo{},
+			AccessRequests: &proto.AccessRequestsFeature{},
+		}
+		entitlements.BackfillFeatures(&features)
+		expected := utils.CloneProtoMsg(&features)
+		require.Equal(t, *expected, handler.GetClusterFeatures())
+	})
+}
+
+func TestFeaturesWatcherBackoff(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		mockClient := &mockedFeatureGetter{features: proto.Features{
+			Kubernetes:     true,
+			Entitlements:   map[string]*proto.EntitlementInfo{},
+			AccessRequests: &proto.AccessRequestsFeature{},
+		}}
+		mockClient.setError(trace.ConnectionProblem(nil, "auth is down"))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		interval := 100 * time.Millisecond
+		handler := &Handler{
+			cfg: Config{
+				FeatureWatchInterval: interval,
+				FeatureWatchJitter:   func(d time.Duration) time.Duration { return d },
+				ProxyClient:          mockClient,
+				Context:              ctx,
+			},
+			clock:           clockwork.NewRealClock(),
+			clusterFeatures: proto.Features{},
+			logger:          slog.Default().With(teleport.ComponentKey, teleport.ComponentWeb),
+		}
+
+		go handler.startFeatureWatcher()
+		synctest.Wait()
+
+		// the initial sync fails and leaves the cached features untouched
+		require.Equal(t, 1, mockClient.pingCount())
+		require.Equal(t, proto.Features{}, handler.GetClusterFeatures())
+
+		// the first retry still happens after one interval and doubles the delay
+		time.Sleep(interval)
+		synctest.Wait()
+		require.Equal(t, 2, mockClient.pingCount())
+
+		// while backing off, a single interval does not trigger another ping
+		time.Sleep(interval)
+		synctest.Wait()
+		require.Equal(t, 2, mockClient.pingCount())
+
+		time.Sleep(interval)
+		synctest.Wait()
+		require.Equal(t, 3, mockClient.pingCount())
+
+		// once auth recovers, the next retry succeeds and the delay resets to
+		// the base interval
+		mockClient.setError(nil)
+		time.Sleep(4 * interval)
+		synctest.Wait()
+		require.Equal(t, 4, mockClient.pingCount())
 
-		// after starting the watcher, handler.GetClusterFeatures should return
-		// values matching the client's response
+		features := proto.Features{
+			Kubernetes:     true,
+			Entitlements:   map[string]*proto.EntitlementInfo{},
+			AccessRequests: &proto.AccessRequestsFeature{},
+		}
+		entitlements.BackfillFeatures(&features)
+		expected := utils.CloneProtoMsg(&features)
+		require.Equal(t, *expected, handler.GetClusterFeatures())
+
+		time.Sleep(interval)
+		synctest.Wait()
+		require.Equal(t, 5, mockClient.pingCount())
+	})
+}
+
+func TestFeaturesWatcher(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		mockClient := &mockedFeatureGetter{features: proto.Features{
+			Kubernetes:     true,
+			Entitlements:   map[string]*proto.EntitlementInfo{},
+			AccessRequests: &proto.AccessRequestsFeature{},
+		}}
+
+		ctx, cancel := context.WithCancel(t.Context())
+
+		handler := &Handler{
+			cfg: Config{
+				FeatureWatchInterval: 100 * time.Millisecond,
+				// the identity jitter keeps the synctest sleeps below aligned
+				// with the watcher's timer
+				FeatureWatchJitter: func(d time.Duration) time.Duration { return d },
+				ProxyClient:        mockClient,
+				Context:            ctx,
+			},
+			clock:           clockwork.NewRealClock(),
+			clusterFeatures: proto.Features{},
+			logger:          slog.Default().With(teleport.ComponentKey, teleport.ComponentWeb),
+		}
+
+		// no successful sync has happened yet
+		require.Zero(t, handler.LastFeatureSync())
+
+		go handler.startFeatureWatcher()
+		synctest.Wait()
+
+		// the initial sync records the sync time
+		require.Equal(t, handler.clock.Now(), handler.LastFeatureSync())
+
+		// the watcher syncs features once immediately on start, so
+		// handler.GetClusterFeatures should return values matching the
+		// client's response before the first interval has elapsed
 		features := proto.Features{
 			Kubernetes:     true,
 			Entitlements:   map[string]*proto.EntitlementInfo{},
@@ -168,3 +472,66 @@ func TestFeaturesWatcher(t *testing.T) {
 		require.NotEqual(t, *notExpected, handler.GetClusterFeatures())
 	})
 }
+
+func TestFeaturesWatcherSurvivesPanic(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		mockClient := &mockedFeatureGetter{features: proto.Features{
+			Kubernetes:     true,
+			Entitlements:   map[string]*proto.EntitlementInfo{},
+			AccessRequests: &proto.AccessRequestsFeature{},
+		}}
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		var mu sync.Mutex
+		calls := 0
+		handler := &Handler{
+			cfg: Config{
+				FeatureWatchInterval: 100 * time.Millisecond,
+				FeatureWatchJitter:   func(d time.Duration) time.Duration { return d },
+				ProxyClient:          mockClient,
+				Context:              ctx,
+				OnFeaturesChanged: func(old, new proto.Features) {
+					mu.Lock()
+					calls++
+					first := calls == 1
+					mu.Unlock()
+					if first {
+						panic("malformed features")
+					}
+				},
+			},
+			clock:           clockwork.NewRealClock(),
+			clusterFeatures: proto.Features{},
+			logger:          slog.Default().With(teleport.ComponentKey, teleport.ComponentWeb),
+		}
+
+		go handler.startFeatureWatcher()
+		synctest.Wait()
+
+		// The initial sync panics in the apply step. The features fetched
+		// before the panic stay cached and the watcher goroutine must
+		// survive.
+		require.True(t, handler.GetClusterFeatures().Kubernetes)
+		mu.Lock()
+		require.Equal(t, 1, calls)
+		mu.Unlock()
+
+		// A feature flip on a later tick proves the watcher kept running
+		// after the recovered panic.
+		features := proto.Features{
+			Kubernetes:     false,
+			Entitlements:   map[string]*proto.EntitlementInfo{},
+			AccessRequests: &proto.AccessRequestsFeature{},
+		}
+		mockClient.setFeatures(features)
+
+		time.Sleep(handler.cfg.FeatureWatchInterval)
+		synctest.Wait()
+		require.False(t, handler.GetClusterFeatures().Kubernetes)
+		mu.Lock()
+		require.Equal(t, 2, calls)
+		mu.Unlock()
+	})
+}
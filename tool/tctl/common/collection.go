@@ -19,6 +19,7 @@
 package common
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,13 +28,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/gravitational/trace"
 
 	"github.com/gravitational/teleport/api/defaults"
 	crownjewelv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/crownjewel/v1"
-	devicepb "github.com/gravitational/teleport/api/gen/proto/go/teleport/devicetrust/v1"
 	healthcheckconfigv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/healthcheckconfig/v1"
-	loginrulepb "github.com/gravitational/teleport/api/gen/proto/go/teleport/loginrule/v1"
 	scopedaccessv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/scopes/access/v1"
 	"github.com/gravitational/teleport/api/gen/proto/go/teleport/vnet/v1"
 	"github.com/gravitational/teleport/api/types"
@@ -42,10 +42,8 @@ import (
 	"github.com/gravitational/teleport/api/types/secreports"
 	apiutils "github.com/gravitational/teleport/api/utils"
 	"github.com/gravitational/teleport/lib/asciitable"
-	"github.com/gravitational/teleport/lib/devicetrust"
 	"github.com/gravitational/teleport/lib/utils"
 	"github.com/gravitational/teleport/tool/common"
-	"github.com/gravitational/teleport/tool/tctl/common/loginrule"
 	"github.com/gravitational/teleport/tool/tctl/common/oktaassignment"
 	"github.com/gravitational/teleport/tool/tctl/common/resources"
 )
@@ -80,35 +78,6 @@ func (r *reverseTunnelCollection) WriteText(w io.Writer, verbose bool) error {
 	return trace.Wrap(err)
 }
 
-type trustedClusterCollection struct {
-	trustedClusters []types.TrustedCluster
-}
-
-func (c *trustedClusterCollection) Resources() (r []types.Resource) {
-	for _, resource := range c.trustedClusters {
-		r = append(r, resource)
-	}
-	return r
-}
-
-func (c *trustedClusterCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{
-		"Name", "Enabled", "Token", "Proxy Address", "Reverse Tunnel Address", "Role Map",
-	})
-	for _, tc := range c.trustedClusters {
-		t.AddRow([]string{
-			tc.GetName(),
-			strconv.FormatBool(tc.GetEnabled()),
-			tc.GetToken(),
-			tc.GetProxyAddress(),
-			tc.GetReverseTunnelAddress(),
-			fmt.Sprintf("%v", tc.CombinedMapping()),
-		})
-	}
-	_, err := t.AsBuffer().WriteTo(w)
-	return trace.Wrap(err)
-}
-
 type remoteClusterCollection struct {
 	remoteClusters []types.RemoteCluster
 }
@@ -137,6 +106,50 @@ func formatLastHeartbeat(t time.Time) string {
 	return apiutils.HumanTimeFormat(t)
 }
 
+// csvCollection is implemented by collections that can emit a stable
+// comma-separated representation of their text table, for tooling that
+// cannot reliably re-parse the human-readable ascii table.
+type csvCollection interface {
+	WriteCSV(w io.Writer, verbose bool) error
+}
+
+// collectionSummary is the aggregate view of a collection emitted by --count.
+type collectionSummary struct {
+	Total      int            `json:"total"`
+	PerVersion map[string]int `json:"per_version"`
+}
+
+// summaryCollection is implemented by collections that can report aggregate
+// counts instead of printing a row per resource.
+type summaryCollection interface {
+	Summary() collectionSummary
+}
+
+// writeSummaryText prints the per-version breakdown as a table followed by
+// the total count.
+func writeSummaryText(s collectionSummary, w io.Writer) error {
+	versions := make([]string, 0, len(s.PerVersion))
+	for version := range s.PerVersion {
+		versions = append(versions, version)
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return semverLess(versions[i], versions[j])
+	})
+	t := asciitable.MakeTable([]string{"Version", "Count"})
+	for _, version := range versions {
+		t.AddRow([]string{version, strconv.Itoa(s.PerVersion[version])})
+	}
+	if _, err := t.AsBuffer().WriteTo(w); err != nil {
+		return trace.Wrap(err)
+	}
+	_, err := fmt.Fprintf(w, "Total: %d\n", s.Total)
+	return trace.Wrap(err)
+}
+
+// writeJSON serializes the collection as a JSON array. A list-style get that
+// matches nothing emits an empty array rather than an error, so automation
+// can tell "no resources" apart from a failed request; gets for a specific
+// name still fail with NotFound before reaching this point.
 func writeJSON(c resources.Collection, w io.Writer) error {
 	return utils.WriteJSONArray(w, c.Resources())
 }
@@ -169,115 +182,115 @@ func (c *semaphoreCollection) WriteText(w io.Writer, verbose bool) error {
 	return trace.Wrap(err)
 }
 
-type netRestrictionsCollection struct {
-	netRestricts types.NetworkRestrictions
-}
-
-type writer struct {
-	w   io.Writer
-	err error
-}
-
-func (w *writer) write(s string) {
-	if w.err == nil {
-		_, w.err = w.w.Write([]byte(s))
-	}
-}
-
-func (c *netRestrictionsCollection) Resources() (r []types.Resource) {
-	r = append(r, c.netRestricts)
-	return
-}
-
-func (c *netRestrictionsCollection) writeList(as []types.AddressCondition, w *writer) {
-	for _, a := range as {
-		w.write(a.CIDR)
-		w.write("\n")
-	}
-}
-
-func (c *netRestrictionsCollection) WriteText(w io.Writer, verbose bool) error {
-	out := &writer{w: w}
-	out.write("ALLOW\n")
-	c.writeList(c.netRestricts.GetAllow(), out)
-
-	out.write("\nDENY\n")
-	c.writeList(c.netRestricts.GetDeny(), out)
-	return trace.Wrap(out.err)
-}
+const (
+	// sortByName sorts text output rows by resource name, the default.
+	sortByName = "name"
+	// sortByVersion sorts text output rows by Teleport version, oldest
+	// first, using semver ordering rather than lexical string ordering.
+	sortByVersion = "version"
+)
 
-type databaseServerCollection struct {
-	servers []types.DatabaseServer
+type kubeServerCollection struct {
+	servers []types.KubeServer
+	// sortBy selects the text output row ordering, one of sortByName
+	// (the default) or sortByVersion.
+	sortBy string
+	// collapsed groups text output rows by cluster name, printing an agent
+	// count and version range instead of a row per server.
+	collapsed bool
 }
 
-func (c *databaseServerCollection) Resources() (r []types.Resource) {
+func (c *kubeServerCollection) Resources() (r []types.Resource) {
 	for _, resource := range c.servers {
 		r = append(r, resource)
 	}
 	return r
 }
 
-func (c *databaseServerCollection) WriteText(w io.Writer, verbose bool) error {
+func (c *kubeServerCollection) WriteText(w io.Writer, verbose bool) error {
+	if c.collapsed {
+		return trace.Wrap(c.writeCollapsedText(w, verbose))
+	}
 	var rows [][]string
 	for _, server := range c.servers {
-		labels := common.FormatLabels(server.GetDatabase().GetAllLabels(), verbose)
-		rows = append(rows, []string{
-			server.GetHostname(),
-			common.FormatResourceName(server.GetDatabase(), verbose),
-			server.GetDatabase().GetProtocol(),
-			server.GetDatabase().GetURI(),
+		kube := server.GetCluster()
+		if kube == nil {
+			continue
+		}
+		labels := common.FormatLabels(kube.GetAllLabels(), verbose)
+		row := []string{
+			common.FormatResourceName(kube, verbose),
 			labels,
 			server.GetTeleportVersion(),
+		}
+		if verbose {
+			row = append(row, server.GetHostname(), formatExpiresIn(server.Expiry()))
+		}
+		rows = append(rows, row)
+	}
+	if c.sortBy == sortByVersion {
+		// Table sorting is lexical, so sort the rows by semver before
+		// building the table instead.
+		sort.SliceStable(rows, func(i, j int) bool {
+			return semverLess(rows[i][2], rows[j][2])
 		})
 	}
-	headers := []string{"Host", "Name", "Protocol", "URI", "Labels", "Version"}
+	headers := []string{"Cluster", "Labels", "Version"}
 	var t asciitable.Table
 	if verbose {
-		t = asciitable.MakeTable(headers, rows...)
+		t = asciitable.MakeTable(append(headers, "Hostname", "Expires In"), rows...)
 	} else {
 		t = asciitable.MakeTableWithTruncatedColumn(headers, rows, "Labels")
 	}
-	// stable sort by hostname then by name.
-	t.SortRowsBy([]int{0, 1}, true)
+	if c.sortBy != sortByVersion {
+		// stable sort by cluster name.
+		t.SortRowsBy([]int{0}, true)
+	}
+
 	_, err := t.AsBuffer().WriteTo(w)
 	return trace.Wrap(err)
 }
 
-func (c *databaseServerCollection) writeJSON(w io.Writer) error {
-	return utils.WriteJSONArray(w, c.servers)
-}
-
-func (c *databaseServerCollection) writeYAML(w io.Writer) error {
-	return utils.WriteYAML(w, c.servers)
-}
-
-type kubeServerCollection struct {
-	servers []types.KubeServer
-}
-
-func (c *kubeServerCollection) Resources() (r []types.Resource) {
-	for _, resource := range c.servers {
-		r = append(r, resource)
+// writeCollapsedText groups the servers by cluster name and prints one row
+// per cluster with the number of agents serving it and the lowest and highest
+// Teleport versions in the group.
+func (c *kubeServerCollection) writeCollapsedText(w io.Writer, verbose bool) error {
+	type group struct {
+		labels     string
+		count      int
+		minVersion string
+		maxVersion string
 	}
-	return r
-}
-
-func (c *kubeServerCollection) WriteText(w io.Writer, verbose bool) error {
-	var rows [][]string
+	groups := make(map[string]*group)
 	for _, server := range c.servers {
 		kube := server.GetCluster()
 		if kube == nil {
 			continue
 		}
-		labels := common.FormatLabels(kube.GetAllLabels(), verbose)
-		rows = append(rows, []string{
-			common.FormatResourceName(kube, verbose),
-			labels,
-			server.GetTeleportVersion(),
-		})
-
+		name := common.FormatResourceName(kube, verbose)
+		version := server.GetTeleportVersion()
+		g, ok := groups[name]
+		if !ok {
+			g = &group{
+				labels:     common.FormatLabels(kube.GetAllLabels(), verbose),
+				minVersion: version,
+				maxVersion: version,
+			}
+			groups[name] = g
+		}
+		g.count++
+		if semverLess(version, g.minVersion) {
+			g.minVersion = version
+		}
+		if semverLess(g.maxVersion, version) {
+			g.maxVersion = version
+		}
 	}
-	headers := []string{"Cluster", "Labels", "Version"}
+	var rows [][]string
+	for name, g := range groups {
+		rows = append(rows, []string{name, g.labels, strconv.Itoa(g.count), g.minVersion, g.maxVersion})
+	}
+	headers := []string{"Cluster", "Labels", "Agents", "Min Version", "Max Version"}
 	var t asciitable.Table
 	if verbose {
 		t = asciitable.MakeTable(headers, rows...)
@@ -291,6 +304,77 @@ func (c *kubeServerCollection) WriteText(w io.Writer, verbose bool) error {
 	return trace.Wrap(err)
 }
 
+// formatExpiresIn describes how long until the given expiry time as a human
+// duration, or a dash for resources that never expire.
+func formatExpiresIn(expiry time.Time) string {
+	if expiry.IsZero() {
+		return "-"
+	}
+	return time.Until(expiry).Round(time.Second).String()
+}
+
+// semverLess orders version strings by semver, so that e.g. v9.x sorts
+// before v10.x. Versions that fail to parse sort after ones that parse,
+// falling back to lexical ordering among themselves.
+func semverLess(a, b string) bool {
+	av, aerr := semver.NewVersion(strings.TrimPrefix(a, "v"))
+	bv, berr := semver.NewVersion(strings.TrimPrefix(b, "v"))
+	switch {
+	case aerr == nil && berr == nil:
+		return av.LessThan(*bv)
+	case aerr == nil:
+		return true
+	case berr == nil:
+		return false
+	}
+	return a < b
+}
+
+// WriteCSV emits the same columns as the text table in CSV form, with the
+// full label set serialized as a single k=v,k2=v2 cell.
+func (c *kubeServerCollection) WriteCSV(w io.Writer, verbose bool) error {
+	headers := []string{"Cluster", "Labels", "Version"}
+	if verbose {
+		headers = append(headers, "Hostname", "Expires In")
+	}
+	out := csv.NewWriter(w)
+	if err := out.Write(headers); err != nil {
+		return trace.Wrap(err)
+	}
+	for _, server := range c.servers {
+		kube := server.GetCluster()
+		if kube == nil {
+			continue
+		}
+		row := []string{
+			common.FormatResourceName(kube, verbose),
+			common.FormatLabels(kube.GetAllLabels(), true),
+			server.GetTeleportVersion(),
+		}
+		if verbose {
+			row = append(row, server.GetHostname(), formatExpiresIn(server.Expiry()))
+		}
+		if err := out.Write(row); err != nil {
+			return trace.Wrap(err)
+		}
+	}
+	out.Flush()
+	return trace.Wrap(out.Error())
+}
+
+// Summary reports the total number of kube servers and how many run each
+// Teleport version.
+func (c *kubeServerCollection) Summary() collectionSummary {
+	perVersion := make(map[string]int)
+	for _, server := range c.servers {
+		perVersion[server.GetTeleportVersion()]++
+	}
+	return collectionSummary{
+		Total:      len(c.servers),
+		PerVersion: perVersion,
+	}
+}
+
 func (c *kubeServerCollection) writeYAML(w io.Writer) error {
 	return utils.WriteYAML(w, c.servers)
 }
@@ -490,55 +574,6 @@ func (c *databaseServiceCollection) WriteText(w io.Writer, verbose bool) error {
 	return trace.Wrap(err)
 }
 
-type loginRuleCollection struct {
-	rules []*loginrulepb.LoginRule
-}
-
-func (l *loginRuleCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"Name", "Priority"})
-	for _, rule := range l.rules {
-		t.AddRow([]string{rule.Metadata.Name, strconv.FormatInt(int64(rule.Priority), 10)})
-	}
-	_, err := t.AsBuffer().WriteTo(w)
-	return trace.Wrap(err)
-}
-
-func (l *loginRuleCollection) Resources() []types.Resource {
-	resources := make([]types.Resource, len(l.rules))
-	for i, rule := range l.rules {
-		resources[i] = loginrule.ProtoToResource(rule)
-	}
-	return resources
-}
-
-type deviceCollection struct {
-	devices []*devicepb.Device
-}
-
-func (c *deviceCollection) Resources() []types.Resource {
-	resources := make([]types.Resource, len(c.devices))
-	for i, dev := range c.devices {
-		resources[i] = types.DeviceToResource(dev)
-	}
-	return resources
-}
-
-func (c *deviceCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"ID", "OS Type", "Asset Tag", "Enrollment Status", "Creation Time", "Last Updated"})
-	for _, device := range c.devices {
-		t.AddRow([]string{
-			device.Id,
-			devicetrust.FriendlyOSType(device.OsType),
-			device.AssetTag,
-			devicetrust.FriendlyDeviceEnrollStatus(device.EnrollStatus),
-			device.CreateTime.AsTime().Format(time.RFC3339),
-			device.UpdateTime.AsTime().Format(time.RFC3339),
-		})
-	}
-	_, err := t.AsBuffer().WriteTo(w)
-	return trace.Wrap(err)
-}
-
 type oktaImportRuleCollection struct {
 	importRules []types.OktaImportRule
 }
@@ -20,7 +20,10 @@ package common
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"maps"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,6 +34,7 @@ import (
 	"github.com/gravitational/teleport/api"
 	"github.com/gravitational/teleport/api/types"
 	"github.com/gravitational/teleport/lib/asciitable"
+	"github.com/gravitational/teleport/lib/services"
 	"github.com/gravitational/teleport/tool/common"
 	"github.com/gravitational/teleport/tool/tctl/common/resources"
 )
@@ -158,11 +162,11 @@ func testKubeServerCollection_writeText(t *testing.T) {
 		},
 		wantVerboseTable: func() string {
 			table := asciitable.MakeTable(
-				[]string{"Cluster", "Labels", "Version"},
-				[]string{"afirstCluster", formatTestLabels(staticLabelsFixture, longLabelFixture, true), api.Version},
-				[]string{"cluster1", formatTestLabels(staticLabelsFixture, nil, true), api.Version},
-				[]string{"cluster2", formatTestLabels(staticLabelsFixture, longLabelFixture, true), api.Version},
-				[]string{"cluster3-eks-us-west-1-123456789012", formatTestLabels(staticLabelsFixture, eksDiscoveredNameLabel, true), api.Version},
+				[]string{"Cluster", "Labels", "Version", "Hostname", "Expires In"},
+				[]string{"afirstCluster", formatTestLabels(staticLabelsFixture, longLabelFixture, true), api.Version, "_", "-"},
+				[]string{"cluster1", formatTestLabels(staticLabelsFixture, nil, true), api.Version, "_", "-"},
+				[]string{"cluster2", formatTestLabels(staticLabelsFixture, longLabelFixture, true), api.Version, "_", "-"},
+				[]string{"cluster3-eks-us-west-1-123456789012", formatTestLabels(staticLabelsFixture, eksDiscoveredNameLabel, true), api.Version, "_", "-"},
 			)
 			return table.AsBuffer().String()
 		},
@@ -170,6 +174,151 @@ func testKubeServerCollection_writeText(t *testing.T) {
 	test.run(t)
 }
 
+// TestKubeServerCollection_writeJSON verifies that the JSON output of the
+// kube_server collection round-trips through services.UnmarshalKubeServer, so
+// scripted `tctl get kube_server --format=json` output can be re-imported.
+func TestKubeServerCollection_writeJSON(t *testing.T) {
+	kubeServers := []types.KubeServer{
+		mustCreateNewKubeServer(t, "cluster1", "host1", "", nil),
+		mustCreateNewKubeServer(t, "cluster2", "host2", "", longLabelFixture),
+	}
+	var buf bytes.Buffer
+	require.NoError(t, (&kubeServerCollection{servers: kubeServers}).writeJSON(&buf))
+
+	var raw []json.RawMessage
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+	require.Len(t, raw, len(kubeServers))
+	for i, item := range raw {
+		server, err := services.UnmarshalKubeServer(item)
+		require.NoError(t, err)
+		require.Empty(t, cmp.Diff(kubeServers[i], server))
+	}
+}
+
+// TestKubeServerCollection_writeTextSortedByVersion verifies that the
+// version sort option orders rows by semver, oldest first, rather than
+// lexically (so v9.x sorts before v10.x).
+func TestKubeServerCollection_writeTextSortedByVersion(t *testing.T) {
+	v9 := mustCreateNewKubeServer(t, "zcluster-old", "host1", "", nil)
+	v9.Spec.Version = "9.3.2"
+	v10 := mustCreateNewKubeServer(t, "acluster-new", "host2", "", nil)
+	v10.Spec.Version = "10.1.0"
+
+	coll := &kubeServerCollection{
+		servers: []types.KubeServer{v10, v9},
+		sortBy:  sortByVersion,
+	}
+	var buf bytes.Buffer
+	require.NoError(t, coll.WriteText(&buf, false))
+	out := buf.String()
+	require.Less(t, strings.Index(out, "zcluster-old"), strings.Index(out, "acluster-new"),
+		"expected the v9 server to sort before the v10 server:\n%s", out)
+}
+
+// TestKubeServerCollection_writeTextCollapsed verifies that collapsed mode
+// prints one row per cluster with the agent count and version range.
+func TestKubeServerCollection_writeTextCollapsed(t *testing.T) {
+	agent1 := mustCreateNewKubeServer(t, "cluster1", "host1", "", nil)
+	agent1.Spec.Version = "9.3.2"
+	agent2 := mustCreateNewKubeServer(t, "cluster1", "host2", "", nil)
+	agent2.Spec.Version = "10.1.0"
+	solo := mustCreateNewKubeServer(t, "cluster2", "host3", "", nil)
+	solo.Spec.Version = "10.1.0"
+
+	coll := &kubeServerCollection{
+		servers:   []types.KubeServer{agent1, agent2, solo},
+		collapsed: true,
+	}
+	var buf bytes.Buffer
+	require.NoError(t, coll.WriteText(&buf, true))
+
+	want := asciitable.MakeTable(
+		[]string{"Cluster", "Labels", "Agents", "Min Version", "Max Version"},
+		[]string{"cluster1", formatTestLabels(staticLabelsFixture, nil, true), "2", "9.3.2", "10.1.0"},
+		[]string{"cluster2", formatTestLabels(staticLabelsFixture, nil, true), "1", "10.1.0", "10.1.0"},
+	)
+	want.SortRowsBy([]int{0}, true)
+	require.Equal(t, want.AsBuffer().String(), buf.String())
+}
+
+// TestKubeServerCollection_WriteCSV verifies that the CSV output matches the
+// text table columns and quotes the label cell so it survives re-parsing.
+func TestKubeServerCollection_WriteCSV(t *testing.T) {
+	server := mustCreateNewKubeServer(t, "cluster1", "host1", "", nil)
+	coll := &kubeServerCollection{servers: []types.KubeServer{server}}
+
+	var buf bytes.Buffer
+	require.NoError(t, coll.WriteCSV(&buf, false))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, []string{"Cluster", "Labels", "Version"}, records[0])
+	require.Equal(t, []string{
+		"cluster1",
+		formatTestLabels(staticLabelsFixture, nil, true),
+		api.Version,
+	}, records[1])
+}
+
+// TestKubeServerCollection_Summary verifies the aggregate counts backing the
+// --count output mode.
+func TestKubeServerCollection_Summary(t *testing.T) {
+	coll := &kubeServerCollection{servers: []types.KubeServer{
+		mustCreateNewKubeServer(t, "cluster1", "host1", "", nil),
+		mustCreateNewKubeServer(t, "cluster2", "host2", "", nil),
+	}}
+	require.Equal(t, collectionSummary{
+		Total:      2,
+		PerVersion: map[string]int{api.Version: 2},
+	}, coll.Summary())
+}
+
+// TestWriteSummaryText verifies the text rendering of --count output,
+// including semver ordering of the per-version breakdown.
+func TestWriteSummaryText(t *testing.T) {
+	summary := collectionSummary{
+		Total:      3,
+		PerVersion: map[string]int{"10.1.0": 1, "9.3.2": 2},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeSummaryText(summary, &buf))
+
+	want := asciitable.MakeTable([]string{"Version", "Count"})
+	want.AddRow([]string{"9.3.2", "2"})
+	want.AddRow([]string{"10.1.0", "1"})
+	require.Equal(t, want.AsBuffer().String()+"Total: 3\n", buf.String())
+}
+
+// TestKubeServerCollection_writeJSONRoundTrip verifies that the generic JSON
+// serialization of a kube server collection can be re-imported through the
+// same unmarshaling path used by tctl create.
+func TestKubeServerCollection_writeJSONRoundTrip(t *testing.T) {
+	server := mustCreateNewKubeServer(t, "cluster1", "host1", "", nil)
+	coll := &kubeServerCollection{servers: []types.KubeServer{server}}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeJSON(coll, &buf))
+
+	var raw []json.RawMessage
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+	require.Len(t, raw, 1)
+
+	imported, err := services.UnmarshalKubeServer(raw[0])
+	require.NoError(t, err)
+	require.Empty(t, cmp.Diff(server, imported))
+}
+
+// TestWriteJSONEmptyCollection pins the contract that a list-style get
+// matching nothing serializes to an empty JSON array instead of null, so
+// scripts can distinguish "no resources" from an error.
+func TestWriteJSONEmptyCollection(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeJSON(&kubeServerCollection{}, &buf))
+	require.JSONEq(t, "[]", buf.String())
+}
+
 func testDatabaseCollection_writeText(t *testing.T) {
 	rdsDiscoveredNameLabel := map[string]string{
 		types.DiscoveredNameLabel: "database",
@@ -225,7 +374,7 @@ func testDatabaseServerCollection_writeText(t *testing.T) {
 			rdsDiscoveredNameLabel),
 	}
 	test := writeTextTest{
-		collection: &databaseServerCollection{servers: dbServers},
+		collection: resources.NewDatabaseServerCollection(dbServers),
 		wantNonVerboseTable: func() string {
 			table := asciitable.MakeTableWithTruncatedColumn(
 				[]string{"Host", "Name", "Protocol", "URI", "Labels", "Version"},
@@ -36,6 +36,7 @@ import (
 	"github.com/gravitational/teleport/lib/utils"
 	commonclient "github.com/gravitational/teleport/tool/tctl/common/client"
 	tctlcfg "github.com/gravitational/teleport/tool/tctl/common/config"
+	"github.com/gravitational/teleport/tool/tctl/common/resources"
 )
 
 // DBCommand implements "tctl db" group of commands.
@@ -109,14 +110,14 @@ func (c *DBCommand) ListDatabases(ctx context.Context, clt *authclient.Client) e
 		return trace.Wrap(err)
 	}
 
-	coll := &databaseServerCollection{servers: servers}
 	switch c.format {
 	case teleport.Text:
+		coll := resources.NewDatabaseServerCollection(servers)
 		return trace.Wrap(coll.WriteText(os.Stdout, c.verbose))
 	case teleport.JSON:
-		return trace.Wrap(coll.writeJSON(os.Stdout))
+		return utils.WriteJSONArray(os.Stdout, servers)
 	case teleport.YAML:
-		return trace.Wrap(coll.writeYAML(os.Stdout))
+		return utils.WriteYAML(os.Stdout, servers)
 	default:
 		return trace.BadParameter("unknown format %q", c.format)
 	}
@@ -19,14 +19,15 @@
 package common
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"reflect"
 	"slices"
-	"sort"
 	"strings"
 	"time"
 
@@ -39,14 +40,11 @@ import (
 	"github.com/gravitational/teleport/api/client/proto"
 	apidefaults "github.com/gravitational/teleport/api/defaults"
 	crownjewelv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/crownjewel/v1"
-	devicepb "github.com/gravitational/teleport/api/gen/proto/go/teleport/devicetrust/v1"
 	healthcheckconfigv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/healthcheckconfig/v1"
-	loginrulepb "github.com/gravitational/teleport/api/gen/proto/go/teleport/loginrule/v1"
 	pluginsv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/plugins/v1"
 	scopedaccessv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/scopes/access/v1"
 	"github.com/gravitational/teleport/api/gen/proto/go/teleport/vnet/v1"
 	"github.com/gravitational/teleport/api/mfa"
-	"github.com/gravitational/teleport/api/trail"
 	"github.com/gravitational/teleport/api/types"
 	"github.com/gravitational/teleport/api/types/externalauditstorage"
 	"github.com/gravitational/teleport/api/types/secreports"
@@ -55,7 +53,6 @@ import (
 	"github.com/gravitational/teleport/lib/auth/authclient"
 	"github.com/gravitational/teleport/lib/client"
 	"github.com/gravitational/teleport/lib/defaults"
-	"github.com/gravitational/teleport/lib/devicetrust"
 	"github.com/gravitational/teleport/lib/itertools/stream"
 	scopedaccess "github.com/gravitational/teleport/lib/scopes/access"
 	scopedutils "github.com/gravitational/teleport/lib/scopes/utils"
@@ -64,7 +61,6 @@ import (
 	"github.com/gravitational/teleport/lib/utils"
 	commonclient "github.com/gravitational/teleport/tool/tctl/common/client"
 	tctlcfg "github.com/gravitational/teleport/tool/tctl/common/config"
-	"github.com/gravitational/teleport/tool/tctl/common/loginrule"
 	"github.com/gravitational/teleport/tool/tctl/common/resources"
 )
 
@@ -85,6 +81,42 @@ type ResourceCommand struct {
 	ttl         string
 	labels      string
 
+	// labelSelector filters 'get' results by label predicates, used by
+	// resource kinds that support it.
+	labelSelector string
+
+	// prefixMatch makes name filters match by prefix instead of exact
+	// name, used by resource kinds that support it.
+	prefixMatch bool
+
+	// sortBy selects the text output ordering for resource kinds that
+	// support it.
+	sortBy string
+
+	// collapsed groups text output by resource instead of printing a row
+	// per serving agent, for resource kinds that support it.
+	collapsed bool
+
+	// limit caps the number of resources fetched by 'get', for resource
+	// kinds that support it. Zero means no limit.
+	limit int
+
+	// where filters 'get' results with a predicate-language expression,
+	// for resource kinds that support it.
+	where string
+
+	// searchKeywords filters 'get' results by fuzzy keyword matching, for
+	// resource kinds that support it.
+	searchKeywords string
+
+	// dryRun validates 'create' payloads without mutating the cluster,
+	// for resource kinds that support it.
+	dryRun bool
+
+	// count prints an aggregate summary instead of the full resource
+	// listing, for resource kinds that support it.
+	count bool
+
 	// filename is the name of the resource, used for 'create'
 	filename string
 
@@ -118,12 +150,9 @@ Same as above, but using JSON output:
 // Initialize allows ResourceCommand to plug itself into the CLI parser
 func (rc *ResourceCommand) Initialize(app *kingpin.Application, _ *tctlcfg.GlobalCLIFlags, config *servicecfg.Config) {
 	rc.CreateHandlers = map[string]ResourceCreateHandler{
-		types.KindTrustedCluster:              rc.createTrustedCluster,
 		types.KindExternalAuditStorage:        rc.createExternalAuditStorage,
-		types.KindNetworkRestrictions:         rc.createNetworkRestrictions,
 		types.KindKubernetesCluster:           rc.createKubeCluster,
-		types.KindLoginRule:                   rc.createLoginRule,
-		types.KindDevice:                      rc.createDevice,
+		types.KindKubeServer:                  rc.createKubeServer,
 		types.KindOktaImportRule:              rc.createOktaImportRule,
 		types.KindIntegration:                 rc.createIntegration,
 		types.KindSecurityReport:              rc.createSecurityReport,
@@ -147,9 +176,10 @@ func (rc *ResourceCommand) Initialize(app *kingpin.Application, _ *tctlcfg.Globa
 	rc.config = config
 
 	rc.createCmd = app.Command("create", "Create or update a Teleport resource from a YAML file.")
-	rc.createCmd.Arg("filename", "resource definition file, empty for stdin").StringVar(&rc.filename)
+	rc.createCmd.Arg("filename", "resource definition file or http(s) URL, empty for stdin").StringVar(&rc.filename)
 	rc.createCmd.Flag("force", "Overwrite the resource if already exists").Short('f').BoolVar(&rc.force)
 	rc.createCmd.Flag("confirm", "Confirm an unsafe or temporary resource update").Hidden().BoolVar(&rc.confirm)
+	rc.createCmd.Flag("dry-run", "Validate the resource without creating or updating it (supported by some resource kinds)").BoolVar(&rc.dryRun)
 
 	rc.updateCmd = app.Command("update", "Update resource fields.")
 	rc.updateCmd.Arg("resource type/resource name", `Resource to update
@@ -169,13 +199,24 @@ func (rc *ResourceCommand) Initialize(app *kingpin.Application, _ *tctlcfg.Globa
 	Examples:
 	$ tctl rm role/devs
 	$ tctl rm cluster/main`).SetValue(&rc.ref)
+	rc.deleteCmd.Flag("labels", "Delete all resources matching a comma-separated list of key=value or key!=value label predicates (supported by some resource kinds)").StringVar(&rc.labelSelector)
+	rc.deleteCmd.Flag("force", "Delete multiple resources matched by --labels without confirmation").Short('f').BoolVar(&rc.force)
+	rc.deleteCmd.Flag("prefix", "Match resources whose name starts with the given name instead of requiring an exact match; an ambiguous prefix is rejected (supported by some resource kinds)").BoolVar(&rc.prefixMatch)
 
 	rc.getCmd = app.Command("get", "Print a YAML declaration of various Teleport resources.")
 	rc.getCmd.Arg("resources", "Resource spec: 'type/[name][,...]' or 'all'").Required().SetValue(&rc.refs)
-	rc.getCmd.Flag("format", "Output format: 'yaml', 'json' or 'text'").Default(teleport.YAML).StringVar(&rc.format)
+	rc.getCmd.Flag("format", "Output format: 'yaml', 'json', 'text' or 'csv'").Default(teleport.YAML).StringVar(&rc.format)
 	rc.getCmd.Flag("namespace", "Namespace of the resources").Hidden().Default(apidefaults.Namespace).StringVar(&rc.namespace)
 	rc.getCmd.Flag("with-secrets", "Include secrets in resources like certificate authorities or OIDC connectors").Default("false").BoolVar(&rc.withSecrets)
+	rc.getCmd.Flag("labels", "Filter resources by a comma-separated list of key=value or key!=value label predicates (supported by some resource kinds)").StringVar(&rc.labelSelector)
+	rc.getCmd.Flag("sort", "Sort text output by 'name' or 'version' (supported by some resource kinds)").Default(sortByName).EnumVar(&rc.sortBy, sortByName, sortByVersion)
+	rc.getCmd.Flag("collapsed", "Collapse text output to one row per resource with an agent count and version range (supported by some resource kinds)").BoolVar(&rc.collapsed)
+	rc.getCmd.Flag("limit", "Maximum number of resources to fetch, 0 for no limit (supported by some resource kinds)").IntVar(&rc.limit)
+	rc.getCmd.Flag("query", queryHelp+" (supported by some resource kinds)").StringVar(&rc.where)
+	rc.getCmd.Flag("search", searchHelp+" (supported by some resource kinds)").StringVar(&rc.searchKeywords)
+	rc.getCmd.Flag("count", "Print the resource count and a per-version breakdown instead of the full listing (supported by some resource kinds)").BoolVar(&rc.count)
 	rc.getCmd.Flag("verbose", "Verbose table output, shows full label output").Short('v').BoolVar(&rc.verbose)
+	rc.getCmd.Flag("prefix", "Match resources whose name starts with the given name instead of requiring an exact match (supported by some resource kinds)").BoolVar(&rc.prefixMatch)
 
 	rc.getCmd.Alias(getHelp)
 
@@ -272,6 +313,22 @@ func (rc *ResourceCommand) Get(ctx context.Context, client *authclient.Client) e
 		return trace.Wrap(err)
 	}
 
+	if rc.count {
+		summaryColl, ok := collection.(summaryCollection)
+		if !ok {
+			return trace.BadParameter("resource type %q does not support --count output", rc.ref.Kind)
+		}
+		summary := summaryColl.Summary()
+		switch rc.format {
+		case teleport.Text:
+			return trace.Wrap(writeSummaryText(summary, rc.Stdout))
+		case teleport.JSON:
+			return trace.Wrap(utils.WriteJSON(rc.Stdout, summary))
+		default:
+			return trace.BadParameter("--count output supports the 'text' and 'json' formats only")
+		}
+	}
+
 	// Note that only YAML is officially supported. Support for text and JSON
 	// is experimental.
 	switch rc.format {
@@ -281,6 +338,12 @@ func (rc *ResourceCommand) Get(ctx context.Context, client *authclient.Client) e
 		return writeYAML(collection, rc.Stdout)
 	case teleport.JSON:
 		return writeJSON(collection, rc.Stdout)
+	case teleport.CSV:
+		csvColl, ok := collection.(csvCollection)
+		if !ok {
+			return trace.BadParameter("resource type %q does not support CSV output", rc.ref.Kind)
+		}
+		return csvColl.WriteCSV(rc.Stdout, rc.verbose)
 	}
 	return trace.BadParameter("unsupported format")
 }
@@ -332,8 +395,14 @@ func (rc *ResourceCommand) Create(ctx context.Context, client *authclient.Client
 	var reader io.Reader
 	if rc.filename == "" {
 		reader = os.Stdin
+	} else if strings.HasPrefix(rc.filename, "http://") || strings.HasPrefix(rc.filename, "https://") {
+		body, err := fetchResourcePayload(ctx, rc.filename)
+		if err != nil {
+			return trace.Wrap(err)
+		}
+		reader = bytes.NewReader(body)
 	} else {
-		f, err := utils.OpenFileAllowingUnsafeLinks(rc.filename)
+		f, err := utils.OpenFileAllowingUnsafeLinks(strings.TrimPrefix(rc.filename, "file://"))
 		if err != nil {
 			return trace.Wrap(err)
 		}
@@ -371,6 +440,7 @@ func (rc *ResourceCommand) Create(ctx context.Context, client *authclient.Client
 			opts := resources.CreateOpts{
 				Force:   rc.force,
 				Confirm: rc.confirm,
+				DryRun:  rc.dryRun,
 			}
 			if err := resourceHandler.Create(ctx, client, raw, opts); err != nil {
 				if trace.IsAlreadyExists(err) {
@@ -402,40 +472,6 @@ func (rc *ResourceCommand) Create(ctx context.Context, client *authclient.Client
 	}
 }
 
-// createTrustedCluster implements `tctl create cluster.yaml` command
-func (rc *ResourceCommand) createTrustedCluster(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
-	tc, err := services.UnmarshalTrustedCluster(raw.Raw, services.DisallowUnknown())
-	if err != nil {
-		return trace.Wrap(err)
-	}
-
-	// check if such cluster already exists:
-	name := tc.GetName()
-	_, err = client.GetTrustedCluster(ctx, name)
-	if err != nil && !trace.IsNotFound(err) {
-		return trace.Wrap(err)
-	}
-
-	exists := (err == nil)
-	if !rc.force && exists {
-		return trace.AlreadyExists("trusted cluster %q already exists", name)
-	}
-
-	//nolint:staticcheck // SA1019. UpsertTrustedCluster is deprecated but will
-	// continue being supported for tctl clients.
-	// TODO(bernardjkim) consider using UpsertTrustedClusterV2 in VX.0.0
-	out, err := client.UpsertTrustedCluster(ctx, tc)
-	if err != nil {
-		return trace.Wrap(err)
-	}
-
-	if out.GetName() != tc.GetName() {
-		fmt.Printf("WARNING: trusted cluster resource %q has been renamed to match root cluster name %q. this will become an error in future teleport versions, please update your configuration to use the correct name.\n", name, out.GetName())
-	}
-	fmt.Printf("trusted cluster %q has been %v\n", out.GetName(), UpsertVerb(exists, rc.force))
-	return nil
-}
-
 // createExternalAuditStorage implements `tctl create external_audit_storage` command.
 func (rc *ResourceCommand) createExternalAuditStorage(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
 	draft, err := services.UnmarshalExternalAuditStorage(raw.Raw, services.DisallowUnknown())
@@ -457,20 +493,6 @@ func (rc *ResourceCommand) createExternalAuditStorage(ctx context.Context, clien
 	return nil
 }
 
-// createNetworkRestrictions implements `tctl create net_restrict.yaml` command.
-func (rc *ResourceCommand) createNetworkRestrictions(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
-	newNetRestricts, err := services.UnmarshalNetworkRestrictions(raw.Raw, services.DisallowUnknown())
-	if err != nil {
-		return trace.Wrap(err)
-	}
-
-	if err := client.SetNetworkRestrictions(ctx, newNetRestricts); err != nil {
-		return trace.Wrap(err)
-	}
-	fmt.Printf("network restrictions have been updated\n")
-	return nil
-}
-
 func (rc *ResourceCommand) createKubeCluster(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
 	cluster, err := services.UnmarshalKubeCluster(raw.Raw, services.DisallowUnknown())
 	if err != nil {
@@ -493,6 +515,36 @@ func (rc *ResourceCommand) createKubeCluster(ctx context.Context, client *authcl
 	return nil
 }
 
+func (rc *ResourceCommand) createKubeServer(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
+	kubeServer, err := services.UnmarshalKubeServer(raw.Raw, services.DisallowUnknown())
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	// Refuse to silently shadow a server that already resolves to the same
+	// name but lives on a different host, unless --force is given.
+	if !rc.force {
+		servers, err := client.GetKubernetesServers(ctx)
+		if err != nil {
+			return trace.Wrap(err)
+		}
+		for _, s := range resources.FilterByNameOrDiscoveredName(servers, kubeServer.GetName()) {
+			if s.GetHostID() != kubeServer.GetHostID() {
+				return trace.AlreadyExists("Kubernetes server %q already exists on host %q, use --force to overwrite it",
+					kubeServer.GetName(), s.GetHostname())
+			}
+		}
+	}
+	if rc.dryRun {
+		fmt.Printf("Kubernetes server %q passed validation, no changes applied (dry run)\n", kubeServer.GetName())
+		return nil
+	}
+	if _, err := client.UpsertKubernetesServer(ctx, kubeServer); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("Kubernetes server %q has been upserted\n", kubeServer.GetName())
+	return nil
+}
+
 func (rc *ResourceCommand) createCrownJewel(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
 	crownJewel, err := services.UnmarshalCrownJewel(raw.Raw, services.DisallowUnknown())
 	if err != nil {
@@ -603,73 +655,6 @@ func (rc *ResourceCommand) updateCrownJewel(ctx context.Context, client *authcli
 	return nil
 }
 
-func (rc *ResourceCommand) createLoginRule(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
-	rule, err := loginrule.UnmarshalLoginRule(raw.Raw)
-	if err != nil {
-		return trace.Wrap(err)
-	}
-
-	loginRuleClient := client.LoginRuleClient()
-	if rc.IsForced() {
-		_, err := loginRuleClient.UpsertLoginRule(ctx, &loginrulepb.UpsertLoginRuleRequest{
-			LoginRule: rule,
-		})
-		if err != nil {
-			return trail.FromGRPC(err)
-		}
-	} else {
-		_, err = loginRuleClient.CreateLoginRule(ctx, &loginrulepb.CreateLoginRuleRequest{
-			LoginRule: rule,
-		})
-		if err != nil {
-			return trail.FromGRPC(err)
-		}
-	}
-	verb := UpsertVerb(false /* we don't know if it existed before */, rc.IsForced() /* force update */)
-	fmt.Printf("login_rule %q has been %s\n", rule.GetMetadata().GetName(), verb)
-	return nil
-}
-
-func (rc *ResourceCommand) createDevice(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
-	res, err := services.UnmarshalDevice(raw.Raw)
-	if err != nil {
-		return trace.Wrap(err)
-	}
-	dev, err := types.DeviceFromResource(res)
-	if err != nil {
-		return trace.Wrap(err)
-	}
-
-	if rc.IsForced() {
-		_, err = client.DevicesClient().UpsertDevice(ctx, &devicepb.UpsertDeviceRequest{
-			Device:           dev,
-			CreateAsResource: true,
-		})
-		// err checked below
-	} else {
-		_, err = client.DevicesClient().CreateDevice(ctx, &devicepb.CreateDeviceRequest{
-			Device:           dev,
-			CreateAsResource: true,
-		})
-		// err checked below
-	}
-	if err != nil {
-		return trail.FromGRPC(err)
-	}
-
-	verb := "created"
-	if rc.IsForced() {
-		verb = "updated"
-	}
-
-	fmt.Printf("Device %v/%v %v\n",
-		dev.AssetTag,
-		devicetrust.FriendlyOSType(dev.OsType),
-		verb,
-	)
-	return nil
-}
-
 func (rc *ResourceCommand) createOktaImportRule(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
 	importRule, err := services.UnmarshalOktaImportRule(raw.Raw, services.DisallowUnknown())
 	if err != nil {
@@ -777,9 +762,11 @@ func (rc *ResourceCommand) Delete(ctx context.Context, client *authclient.Client
 		types.KindSessionRecordingConfig,
 		types.KindInstaller,
 		types.KindUIConfig,
-		types.KindNetworkRestrictions,
 	}
-	if !slices.Contains(singletonResources, rc.ref.Kind) && (rc.ref.Kind == "" || rc.ref.Name == "") {
+	// Kube servers may be deleted in bulk by label selector instead of by
+	// name.
+	bulkKubeServerDelete := rc.ref.Kind == types.KindKubeServer && rc.labelSelector != ""
+	if !slices.Contains(singletonResources, rc.ref.Kind) && (rc.ref.Kind == "" || rc.ref.Name == "") && !bulkKubeServerDelete {
 		return trace.BadParameter("provide a full resource name to delete, for example:\n$ tctl rm cluster/east\n")
 	}
 
@@ -789,11 +776,6 @@ func (rc *ResourceCommand) Delete(ctx context.Context, client *authclient.Client
 			return trace.Wrap(err)
 		}
 		fmt.Printf("reverse tunnel %v has been deleted\n", rc.ref.Name)
-	case types.KindTrustedCluster:
-		if err = client.DeleteTrustedCluster(ctx, rc.ref.Name); err != nil {
-			return trace.Wrap(err)
-		}
-		fmt.Printf("trusted cluster %q has been deleted\n", rc.ref.Name)
 	case types.KindRemoteCluster:
 		if err = client.DeleteRemoteCluster(ctx, rc.ref.Name); err != nil {
 			return trace.Wrap(err)
@@ -826,29 +808,6 @@ func (rc *ResourceCommand) Delete(ctx context.Context, client *authclient.Client
 			}
 			fmt.Printf("draft External Audit Storage configuration has been deleted\n")
 		}
-	case types.KindDatabaseServer:
-		servers, err := client.GetDatabaseServers(ctx, apidefaults.Namespace)
-		if err != nil {
-			return trace.Wrap(err)
-		}
-		resDesc := "database server"
-		servers = resources.FilterByNameOrDiscoveredName(servers, rc.ref.Name)
-		name, err := resources.GetOneResourceNameToDelete(servers, rc.ref, resDesc)
-		if err != nil {
-			return trace.Wrap(err)
-		}
-		for _, s := range servers {
-			err := client.DeleteDatabaseServer(ctx, apidefaults.Namespace, s.GetHostID(), name)
-			if err != nil {
-				return trace.Wrap(err)
-			}
-		}
-		fmt.Printf("%s %q has been deleted\n", resDesc, name)
-	case types.KindNetworkRestrictions:
-		if err = resetNetworkRestrictions(ctx, client); err != nil {
-			return trace.Wrap(err)
-		}
-		fmt.Printf("network restrictions have been reset to defaults (allow all)\n")
 	case types.KindKubernetesCluster:
 		// TODO(okraport) DELETE IN v21.0.0, replace with regular Collect
 		clusters, err := clientutils.CollectWithFallback(ctx, client.ListKubernetesClusters, client.GetKubernetesClusters)
@@ -876,7 +835,35 @@ func (rc *ResourceCommand) Delete(ctx context.Context, client *authclient.Client
 			return trace.Wrap(err)
 		}
 		resDesc := "Kubernetes server"
-		servers = resources.FilterByNameOrDiscoveredName(servers, rc.ref.Name)
+		if rc.labelSelector != "" {
+			servers, err = resources.FilterByLabelSelector(servers, rc.labelSelector)
+			if err != nil {
+				return trace.Wrap(err)
+			}
+			if rc.ref.Name != "" {
+				servers = resources.FilterByNameOrDiscoveredName(servers, rc.ref.Name)
+			}
+			if len(servers) == 0 {
+				return trace.NotFound("no Kubernetes servers match label selector %q", rc.labelSelector)
+			}
+			if !rc.force {
+				return trace.BadParameter("deleting %d Kubernetes servers matching label selector %q, rerun with --force to confirm", len(servers), rc.labelSelector)
+			}
+			for _, s := range servers {
+				if err := client.DeleteKubernetesServer(ctx, s.GetHostID(), s.GetName()); err != nil {
+					return trace.Wrap(err)
+				}
+				fmt.Printf("%s %q on host %q has been deleted\n", resDesc, s.GetName(), s.GetHostname())
+			}
+			return nil
+		}
+		if rc.prefixMatch {
+			servers = resources.FilterByNameOrDiscoveredNamePrefix(servers, rc.ref.Name)
+		} else {
+			servers = resources.FilterByNameOrDiscoveredName(servers, rc.ref.Name)
+		}
+		// An ambiguous prefix still goes through the one-name check below so
+		// several distinct servers are never deleted by accident.
 		name, err := resources.GetOneResourceNameToDelete(servers, rc.ref, resDesc)
 		if err != nil {
 			return trace.Wrap(err)
@@ -888,29 +875,6 @@ func (rc *ResourceCommand) Delete(ctx context.Context, client *authclient.Client
 			}
 		}
 		fmt.Printf("%s %q has been deleted\n", resDesc, name)
-	case types.KindLoginRule:
-		loginRuleClient := client.LoginRuleClient()
-		_, err := loginRuleClient.DeleteLoginRule(ctx, &loginrulepb.DeleteLoginRuleRequest{
-			Name: rc.ref.Name,
-		})
-		if err != nil {
-			return trail.FromGRPC(err)
-		}
-		fmt.Printf("login rule %q has been deleted\n", rc.ref.Name)
-	case types.KindDevice:
-		remote := client.DevicesClient()
-		device, err := findDeviceByIDOrTag(ctx, remote, rc.ref.Name)
-		if err != nil {
-			return trace.Wrap(err)
-		}
-
-		if _, err := remote.DeleteDevice(ctx, &devicepb.DeleteDeviceRequest{
-			DeviceId: device[0].Id,
-		}); err != nil {
-			return trace.Wrap(err)
-		}
-		fmt.Printf("Device %q removed\n", rc.ref.Name)
-
 	case types.KindIntegration:
 		if err := client.DeleteIntegration(ctx, rc.ref.Name); err != nil {
 			return trace.Wrap(err)
@@ -968,8 +932,57 @@ func (rc *ResourceCommand) Delete(ctx context.Context, client *authclient.Client
 	return nil
 }
 
-func resetNetworkRestrictions(ctx context.Context, client *authclient.Client) error {
-	return trace.Wrap(client.DeleteNetworkRestrictions(ctx))
+const (
+	// resourcePayloadSizeLimit caps the size of resource definitions
+	// fetched from a URL so a misconfigured endpoint cannot exhaust
+	// memory.
+	resourcePayloadSizeLimit = 10 * 1024 * 1024
+	// resourcePayloadFetchTimeout bounds how long a remote fetch of a
+	// resource definition may take.
+	resourcePayloadFetchTimeout = 30 * time.Second
+)
+
+// fetchResourcePayload downloads a resource definition from an http(s) URL,
+// enforcing a response size limit and a fetch timeout and rejecting non-2xx
+// responses.
+func fetchResourcePayload(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, resourcePayloadFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, trace.BadParameter("fetching resource definition from %v: unexpected status %v", url, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, resourcePayloadSizeLimit+1))
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+	if len(body) > resourcePayloadSizeLimit {
+		return nil, trace.LimitExceeded("resource definition at %v exceeds the %d byte limit", url, resourcePayloadSizeLimit)
+	}
+	return body, nil
+}
+
+// parseSearchKeywords splits a comma-separated --search value into the
+// keyword list understood by the resource listing APIs.
+func parseSearchKeywords(spec string) []string {
+	return client.ParseSearchKeywords(spec, ',')
+}
+
+// kubeServerPredicateError surfaces predicate parse failures from the server
+// with usage guidance rather than a bare gRPC error.
+func kubeServerPredicateError(err error) error {
+	if utils.IsPredicateError(err) {
+		return utils.PredicateError{Err: err}
+	}
+	return err
 }
 
 // UpdateFields updates select resource fields: expiry and labels
@@ -1037,7 +1050,7 @@ func (rc *ResourceCommand) getCollection(ctx context.Context, client *authclient
 
 	// Looking if the resource has been converted to the handler format.
 	if handler, found := resources.Handlers()[rc.ref.Kind]; found {
-		coll, err := handler.Get(ctx, client, rc.ref, resources.GetOpts{WithSecrets: rc.withSecrets})
+		coll, err := handler.Get(ctx, client, rc.ref, resources.GetOpts{WithSecrets: rc.withSecrets, Labels: rc.labelSelector, Where: rc.where, PrefixMatch: rc.prefixMatch})
 		if err != nil {
 			if trace.IsNotImplemented(err) {
 				return nil, trace.BadParameter("getting %q is not supported", rc.ref.String())
@@ -1060,25 +1073,6 @@ func (rc *ResourceCommand) getCollection(ctx context.Context, client *authclient
 		}
 
 		return &reverseTunnelCollection{tunnels: tunnels}, nil
-	case types.KindTrustedCluster:
-		if rc.ref.Name == "" {
-			// TODO(okraport): DELETE IN v21.0.0, replace with regular Collect
-			trustedClusters, err := clientutils.CollectWithFallback(
-				ctx,
-				client.ListTrustedClusters,
-				client.GetTrustedClusters,
-			)
-			if err != nil {
-				return nil, trace.Wrap(err)
-			}
-
-			return &trustedClusterCollection{trustedClusters: trustedClusters}, nil
-		}
-		trustedCluster, err := client.GetTrustedCluster(ctx, rc.ref.Name)
-		if err != nil {
-			return nil, trace.Wrap(err)
-		}
-		return &trustedClusterCollection{trustedClusters: []types.TrustedCluster{trustedCluster}}, nil
 	case types.KindRemoteCluster:
 		if rc.ref.Name == "" {
 			remoteClusters, err := client.GetRemoteClusters(ctx)
@@ -1111,43 +1105,67 @@ func (rc *ResourceCommand) getCollection(ctx context.Context, client *authclient
 		}
 		return &semaphoreCollection{sems: sems}, nil
 	case types.KindSessionRecordingConfig:
-	case types.KindDatabaseServer:
-		servers, err := client.GetDatabaseServers(ctx, rc.namespace)
-		if err != nil {
-			return nil, trace.Wrap(err)
-		}
-		if rc.ref.Name == "" {
-			return &databaseServerCollection{servers: servers}, nil
-		}
-
-		servers = resources.FilterByNameOrDiscoveredName(servers, rc.ref.Name)
-		if len(servers) == 0 {
-			return nil, trace.NotFound("database server %q not found", rc.ref.Name)
-		}
-		return &databaseServerCollection{servers: servers}, nil
 	case types.KindKubeServer:
-		servers, err := client.GetKubernetesServers(ctx)
+		// Predicate and search filters are evaluated server-side while
+		// paging.
+		req := &proto.ListResourcesRequest{
+			ResourceType:        types.KindKubeServer,
+			Namespace:           apidefaults.Namespace,
+			PredicateExpression: rc.where,
+			SearchKeywords:      parseSearchKeywords(rc.searchKeywords),
+		}
+		// Without client-side filters the fetch can stop paging as soon
+		// as the limit is reached instead of collecting the whole fleet.
+		if rc.limit > 0 && rc.ref.Name == "" && rc.labelSelector == "" {
+			var servers []types.KubeServer
+			for {
+				page, err := apiclient.GetResourcePage[types.KubeServer](ctx, client, req)
+				if err != nil {
+					return nil, trace.Wrap(kubeServerPredicateError(err))
+				}
+				servers = append(servers, page.Resources...)
+				if len(servers) >= rc.limit || page.NextKey == "" {
+					break
+				}
+				req.StartKey = page.NextKey
+			}
+			if len(servers) > rc.limit {
+				servers = servers[:rc.limit]
+			}
+			return &kubeServerCollection{servers: servers, sortBy: rc.sortBy, collapsed: rc.collapsed}, nil
+		}
+		servers, err := apiclient.GetAllResources[types.KubeServer](ctx, client, req)
 		if err != nil {
-			return nil, trace.Wrap(err)
+			return nil, trace.Wrap(kubeServerPredicateError(err))
 		}
-		if rc.ref.Name == "" {
-			return &kubeServerCollection{servers: servers}, nil
+		// The name filter runs first so that a label selector further
+		// narrows the name matches.
+		if rc.ref.Name != "" {
+			altNameFn := func(r types.KubeServer) string {
+				return r.GetHostname()
+			}
+			if rc.prefixMatch {
+				servers = resources.FilterByNameOrDiscoveredNamePrefix(servers, rc.ref.Name, altNameFn)
+			} else {
+				servers = resources.FilterByNameOrDiscoveredName(servers, rc.ref.Name, altNameFn)
+			}
+			if len(servers) == 0 {
+				return nil, trace.NotFound("Kubernetes server %q not found", rc.ref.Name)
+			}
 		}
-		altNameFn := func(r types.KubeServer) string {
-			return r.GetHostname()
+		if rc.labelSelector != "" {
+			servers, err = resources.FilterByLabelSelector(servers, rc.labelSelector)
+			if err != nil {
+				return nil, trace.Wrap(err)
+			}
 		}
-		servers = resources.FilterByNameOrDiscoveredName(servers, rc.ref.Name, altNameFn)
-		if len(servers) == 0 {
-			return nil, trace.NotFound("Kubernetes server %q not found", rc.ref.Name)
+		// The limit applies after filtering so that it caps the matches
+		// rather than the candidates.
+		if rc.limit > 0 && len(servers) > rc.limit {
+			servers = servers[:rc.limit]
 		}
-		return &kubeServerCollection{servers: servers}, nil
+		return &kubeServerCollection{servers: servers, sortBy: rc.sortBy, collapsed: rc.collapsed}, nil
 
-	case types.KindNetworkRestrictions:
-		nr, err := client.GetNetworkRestrictions(ctx)
-		if err != nil {
-			return nil, trace.Wrap(err)
-		}
-		return &netRestrictionsCollection{nr}, nil
 	case types.KindKubernetesCluster:
 		// TODO(okraport) DELETE IN v21.0.0, replace with regular Collect
 		clusters, err := clientutils.CollectWithFallback(ctx, client.ListKubernetesClusters, client.GetKubernetesClusters)
@@ -1196,69 +1214,6 @@ func (rc *ResourceCommand) getCollection(ctx context.Context, client *authclient
 		}
 
 		return &databaseServiceCollection{databaseServices: databaseServices}, nil
-	case types.KindLoginRule:
-		loginRuleClient := client.LoginRuleClient()
-		if rc.ref.Name == "" {
-			rules, err := stream.Collect(clientutils.Resources(ctx, func(ctx context.Context, limit int, token string) ([]*loginrulepb.LoginRule, string, error) {
-				resp, err := loginRuleClient.ListLoginRules(ctx, &loginrulepb.ListLoginRulesRequest{
-					PageSize:  int32(limit),
-					PageToken: token,
-				})
-				return resp.GetLoginRules(), resp.GetNextPageToken(), trace.Wrap(err)
-			}))
-			if err != nil {
-				return nil, trace.Wrap(err)
-			}
-
-			return &loginRuleCollection{rules}, trace.Wrap(err)
-		}
-		rule, err := loginRuleClient.GetLoginRule(ctx, &loginrulepb.GetLoginRuleRequest{
-			Name: rc.ref.Name,
-		})
-		return &loginRuleCollection{[]*loginrulepb.LoginRule{rule}}, trail.FromGRPC(err)
-	case types.KindDevice:
-		remote := client.DevicesClient()
-		if rc.ref.Name != "" {
-			resp, err := remote.FindDevices(ctx, &devicepb.FindDevicesRequest{
-				IdOrTag: rc.ref.Name,
-			})
-			if err != nil {
-				return nil, trace.Wrap(err)
-			}
-
-			return &deviceCollection{resp.Devices}, nil
-		}
-
-		req := &devicepb.ListDevicesRequest{
-			View: devicepb.DeviceView_DEVICE_VIEW_RESOURCE,
-		}
-		var devs []*devicepb.Device
-		for {
-			resp, err := remote.ListDevices(ctx, req)
-			if err != nil {
-				return nil, trace.Wrap(err)
-			}
-
-			devs = append(devs, resp.Devices...)
-
-			if resp.NextPageToken == "" {
-				break
-			}
-			req.PageToken = resp.NextPageToken
-		}
-
-		sort.Slice(devs, func(i, j int) bool {
-			d1 := devs[i]
-			d2 := devs[j]
-
-			if d1.AssetTag == d2.AssetTag {
-				return d1.OsType < d2.OsType
-			}
-
-			return d1.AssetTag < d2.AssetTag
-		})
-
-		return &deviceCollection{devices: devs}, nil
 	case types.KindOktaImportRule:
 		if rc.ref.Name != "" {
 			importRule, err := client.OktaClient().GetOktaImportRule(ctx, rc.ref.Name)
@@ -1471,29 +1426,6 @@ func UpsertVerb(exists bool, force bool) string {
 	return "created"
 }
 
-func findDeviceByIDOrTag(ctx context.Context, remote devicepb.DeviceTrustServiceClient, idOrTag string) ([]*devicepb.Device, error) {
-	resp, err := remote.FindDevices(ctx, &devicepb.FindDevicesRequest{
-		IdOrTag: idOrTag,
-	})
-	switch {
-	case err != nil:
-		return nil, trace.Wrap(err)
-	case len(resp.Devices) == 0:
-		return nil, trace.NotFound("device %q not found", idOrTag)
-	case len(resp.Devices) == 1:
-		return resp.Devices, nil
-	}
-
-	// Do we have an ID match?
-	for _, dev := range resp.Devices {
-		if dev.Id == idOrTag {
-			return []*devicepb.Device{dev}, nil
-		}
-	}
-
-	return nil, trace.BadParameter("found multiple devices for asset tag %q, please retry using the device ID instead", idOrTag)
-}
-
 func (rc *ResourceCommand) createSecurityReport(ctx context.Context, client *authclient.Client, raw services.UnknownResource) error {
 	in, err := services.UnmarshalSecurityReport(raw.Raw, services.DisallowUnknown())
 	if err != nil {
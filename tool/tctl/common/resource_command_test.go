@@ -23,6 +23,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -2843,3 +2845,33 @@ func TestPluginResourceWrapper(t *testing.T) {
 		})
 	}
 }
+
+// TestFetchResourcePayload exercises the URL source for tctl create: happy
+// path, non-2xx rejection, and the response size limit.
+func TestFetchResourcePayload(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/role.yaml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "kind: role\n")
+	})
+	mux.HandleFunc("/missing.yaml", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	mux.HandleFunc("/huge.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(bytes.Repeat([]byte("a"), resourcePayloadSizeLimit+1))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	body, err := fetchResourcePayload(ctx, srv.URL+"/role.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "kind: role\n", string(body))
+
+	_, err = fetchResourcePayload(ctx, srv.URL+"/missing.yaml")
+	require.True(t, trace.IsBadParameter(err), "expected BadParameter, got %v", err)
+
+	_, err = fetchResourcePayload(ctx, srv.URL+"/huge.yaml")
+	require.True(t, trace.IsLimitExceeded(err), "expected LimitExceeded, got %v", err)
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/gravitational/trace"
@@ -36,6 +38,12 @@ import (
 
 type accessListCollection struct {
 	accessLists []*accesslist.AccessList
+	// fetchMemberCounts maps each access list name to its total member
+	// count (users and nested lists). Members are stored separately from
+	// the list itself and counting them costs one call per list, so the
+	// counts are only fetched when text output renders them and a missing
+	// entry leaves the cell empty rather than failing the get.
+	fetchMemberCounts func() map[string]uint32
 }
 
 func (c *accessListCollection) Resources() []types.Resource {
@@ -47,13 +55,28 @@ func (c *accessListCollection) Resources() []types.Resource {
 }
 
 func (c *accessListCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"Name", "Title", "Review Frequency", "Next Audit Date"})
+	now := time.Now()
+	var memberCounts map[string]uint32
+	if c.fetchMemberCounts != nil {
+		memberCounts = c.fetchMemberCounts()
+	}
+	t := asciitable.MakeTable([]string{"Name", "Title", "Owners", "Members", "Review Frequency", "Next Audit Date"})
 	for _, al := range c.accessLists {
+		members := ""
+		if count, ok := memberCounts[al.GetName()]; ok {
+			members = strconv.FormatUint(uint64(count), 10)
+		}
+		nextAuditDate := al.Spec.Audit.NextAuditDate.Format(time.RFC822)
+		if !al.Spec.Audit.NextAuditDate.IsZero() && al.Spec.Audit.NextAuditDate.Before(now) {
+			nextAuditDate += " (overdue)"
+		}
 		t.AddRow([]string{
 			al.GetName(),
 			al.Spec.Title,
+			strconv.Itoa(len(al.GetOwners())),
+			members,
 			al.Spec.Audit.Recurrence.Frequency.String(),
-			al.Spec.Audit.NextAuditDate.Format(time.RFC822),
+			nextAuditDate,
 		})
 	}
 	_, err := t.AsBuffer().WriteTo(w)
@@ -64,6 +87,7 @@ func accessListHandler() Handler {
 	return Handler{
 		getHandler:    getAccessList,
 		createHandler: createAccessList,
+		updateHandler: updateAccessList,
 		deleteHandler: deleteAccessList,
 		singleton:     false,
 		mfaRequired:   true,
@@ -78,7 +102,11 @@ func getAccessList(ctx context.Context, client *authclient.Client, ref services.
 		if err != nil {
 			return nil, trace.Wrap(err)
 		}
-		return &accessListCollection{accessLists: []*accesslist.AccessList{resource}}, nil
+		collection := &accessListCollection{accessLists: []*accesslist.AccessList{resource}}
+		collection.fetchMemberCounts = func() map[string]uint32 {
+			return countAccessListMembers(ctx, client, collection.accessLists)
+		}
+		return collection, nil
 	}
 
 	accessLists, err := stream.Collect(
@@ -90,9 +118,34 @@ func getAccessList(ctx context.Context, client *authclient.Client, ref services.
 				})
 		}),
 	)
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
 
-	return &accessListCollection{accessLists: accessLists}, trace.Wrap(err)
+	collection := &accessListCollection{accessLists: accessLists}
+	collection.fetchMemberCounts = func() map[string]uint32 {
+		return countAccessListMembers(ctx, client, accessLists)
+	}
+	return collection, nil
+}
 
+// countAccessListMembers counts the members (users and nested lists) of each
+// access list. Member counts live server-side, one call per list. Lists whose
+// count cannot be fetched, because the user lacks permission to read members
+// or the cluster predates the counting RPC, are left out of the map so they
+// render with an unknown count instead of failing the whole get.
+func countAccessListMembers(ctx context.Context, client *authclient.Client, accessLists []*accesslist.AccessList) map[string]uint32 {
+	counts := make(map[string]uint32, len(accessLists))
+	for _, al := range accessLists {
+		users, lists, err := client.AccessListClient().CountAccessListMembers(ctx, al.GetName())
+		if err != nil {
+			slog.WarnContext(ctx, "failed to count access list members; leaving count empty",
+				"access_list", al.GetName(), "error", err)
+			continue
+		}
+		counts[al.GetName()] = users + lists
+	}
+	return counts
 }
 
 // createAccessList implements `tctl create accesslist/my-list` command.
@@ -121,6 +174,24 @@ func createAccessList(ctx context.Context, client *authclient.Client, raw servic
 
 }
 
+// updateAccessList implements `tctl update accesslist/my-list` command.
+func updateAccessList(ctx context.Context, client *authclient.Client, raw services.UnknownResource, opts CreateOpts) error {
+	accessList, err := services.UnmarshalAccessList(raw.Raw, services.DisallowUnknown())
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	if _, err := client.AccessListClient().GetAccessList(ctx, accessList.GetName()); err != nil {
+		return trace.Wrap(err)
+	}
+
+	if _, err := client.AccessListClient().UpsertAccessList(ctx, accessList); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("Access list %q has been updated\n", accessList.GetName())
+	return nil
+}
+
 // deleteAccessList implements `tctl rm accesslist/my-list` command.
 func deleteAccessList(ctx context.Context, client *authclient.Client, ref services.Ref) error {
 	if err := client.AccessListClient().DeleteAccessList(ctx, ref.Name); err != nil {
@@ -0,0 +1,80 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types/accesslist"
+	"github.com/gravitational/teleport/api/types/header"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func mustCreateNewAccessList(t *testing.T, name string, owners []string, nextAuditDate time.Time) *accesslist.AccessList {
+	t.Helper()
+	ownerList := make([]accesslist.Owner, 0, len(owners))
+	for _, owner := range owners {
+		ownerList = append(ownerList, accesslist.Owner{Name: owner})
+	}
+	al, err := accesslist.NewAccessList(
+		header.Metadata{
+			Name: name,
+		},
+		accesslist.Spec{
+			Title:  "Title " + name,
+			Owners: ownerList,
+			Audit: accesslist.Audit{
+				NextAuditDate: nextAuditDate,
+			},
+			Grants: accesslist.Grants{
+				Roles: []string{"access"},
+			},
+		},
+	)
+	require.NoError(t, err)
+	return al
+}
+
+func TestAccessListCollection_writeText(t *testing.T) {
+	overdue := time.Now().Add(-24 * time.Hour).UTC()
+	upcoming := time.Now().Add(24 * time.Hour).UTC()
+	accessLists := []*accesslist.AccessList{
+		mustCreateNewAccessList(t, "oncall", []string{"alice", "bob"}, overdue),
+		mustCreateNewAccessList(t, "auditors", []string{"carol"}, upcoming),
+	}
+	collection := &accessListCollection{
+		accessLists: accessLists,
+		fetchMemberCounts: func() map[string]uint32 {
+			return map[string]uint32{
+				"oncall":   7,
+				"auditors": 2,
+			}
+		},
+	}
+
+	table := asciitable.MakeTable(
+		[]string{"Name", "Title", "Owners", "Members", "Review Frequency", "Next Audit Date"},
+		[]string{"oncall", "Title oncall", "2", "7", accessLists[0].Spec.Audit.Recurrence.Frequency.String(), overdue.Format(time.RFC822) + " (overdue)"},
+		[]string{"auditors", "Title auditors", "1", "2", accessLists[1].Spec.Audit.Recurrence.Frequency.String(), upcoming.Format(time.RFC822)},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
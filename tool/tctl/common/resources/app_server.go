@@ -93,7 +93,7 @@ func listAppServersWithFilter(ctx context.Context, client *authclient.Client, pr
 }
 
 func getAppServer(ctx context.Context, client *authclient.Client, ref services.Ref, opts GetOpts) (Collection, error) {
-	appServers, err := listAppServersWithFilter(ctx, client, makeNamePredicate(ref.Name))
+	appServers, err := listAppServersWithFilter(ctx, client, combinePredicates(makeNamePredicate(ref.Name), opts.Where))
 	if err != nil {
 		return nil, trace.Wrap(err)
 	}
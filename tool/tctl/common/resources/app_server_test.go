@@ -0,0 +1,73 @@
+/*
+ * Teleport
+ * Copyright (C) 2025  Gravitational, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+	"github.com/gravitational/teleport/tool/common"
+)
+
+func mustCreateNewAppServer(t *testing.T, name, publicAddr, hostname string, labels map[string]string) types.AppServer {
+	t.Helper()
+	app, err := types.NewAppV3(types.Metadata{
+		Name:   name,
+		Labels: labels,
+	}, types.AppSpecV3{
+		URI:        "http://localhost:8080",
+		PublicAddr: publicAddr,
+	})
+	require.NoError(t, err)
+	appServer, err := types.NewAppServerV3FromApp(app, hostname, uuid.New().String())
+	require.NoError(t, err)
+	return appServer
+}
+
+func TestAppServerCollection_writeText(t *testing.T) {
+	labels := map[string]string{"env": "prod"}
+	servers := []types.AppServer{
+		mustCreateNewAppServer(t, "app1", "app1.example.com", "host1", labels),
+		mustCreateNewAppServer(t, "app2", "app2.example.com", "host2", nil),
+	}
+
+	makeRows := func(verbose bool) [][]string {
+		rows := make([][]string, 0, len(servers))
+		for _, server := range servers {
+			app := server.GetApp()
+			rows = append(rows, []string{
+				server.GetHostname(), app.GetName(), app.GetProtocol(), app.GetPublicAddr(), app.GetURI(),
+				common.FormatLabels(app.GetAllLabels(), verbose), server.GetTeleportVersion(),
+			})
+		}
+		return rows
+	}
+	headers := []string{"Host", "Name", "Type", "Public Address", "URI", "Labels", "Version"}
+
+	verboseRows := makeRows(true)
+	verboseTable := asciitable.MakeTable(headers, verboseRows...)
+	nonVerboseTable := asciitable.MakeTableWithTruncatedColumn(headers, makeRows(false), "Labels")
+
+	collectionFormatTest(t, NewAppServerCollection(servers),
+		verboseTable.AsBuffer().String(), nonVerboseTable.AsBuffer().String())
+}
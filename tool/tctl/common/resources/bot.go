@@ -24,6 +24,7 @@ import (
 
 	"github.com/gravitational/trace"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	machineidv1pb "github.com/gravitational/teleport/api/gen/proto/go/teleport/machineid/v1"
 	"github.com/gravitational/teleport/api/types"
@@ -46,12 +47,19 @@ func (c *botCollection) Resources() []types.Resource {
 	return resources
 }
 
+// WriteText prints bot metadata only: join token secrets are never part of
+// the output, verbose or not.
 func (c *botCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"Name", "Roles"})
+	t := asciitable.MakeTable([]string{"Name", "Roles", "Max Session TTL"})
 	for _, b := range c.bots {
+		var maxSessionTTL string
+		if b.Spec.MaxSessionTtl != nil {
+			maxSessionTTL = b.Spec.MaxSessionTtl.AsDuration().String()
+		}
 		t.AddRow([]string{
 			b.Metadata.Name,
 			strings.Join(b.Spec.Roles, ", "),
+			maxSessionTTL,
 		})
 	}
 	_, err := t.AsBuffer().WriteTo(w)
@@ -63,6 +71,7 @@ func botHandler() Handler {
 		getHandler:    getBot,
 		deleteHandler: deleteBot,
 		createHandler: createBot,
+		updateHandler: updateBot,
 		singleton:     false,
 		mfaRequired:   true,
 		description:   "Represents the identity of a machine or workload within Teleport.",
@@ -133,6 +142,31 @@ func createBot(
 	return nil
 }
 
+func updateBot(
+	ctx context.Context,
+	client *authclient.Client,
+	raw services.UnknownResource,
+	opts CreateOpts,
+) error {
+	bot := &machineidv1pb.Bot{}
+	if err := (protojson.UnmarshalOptions{}).Unmarshal(raw.Raw, bot); err != nil {
+		return trace.Wrap(err)
+	}
+	// The whole spec is replaced, matching the replace semantics of
+	// tctl update for other resources.
+	_, err := client.BotServiceClient().UpdateBot(ctx, &machineidv1pb.UpdateBotRequest{
+		Bot: bot,
+		UpdateMask: &fieldmaskpb.FieldMask{
+			Paths: []string{"spec.roles", "spec.traits", "spec.max_session_ttl"},
+		},
+	})
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("Bot %q has been updated\n", bot.Metadata.Name)
+	return nil
+}
+
 func deleteBot(
 	ctx context.Context,
 	client *authclient.Client,
@@ -0,0 +1,62 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	headerv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/header/v1"
+	machineidv1pb "github.com/gravitational/teleport/api/gen/proto/go/teleport/machineid/v1"
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func newBot(name string, roles []string, maxSessionTTL time.Duration) *machineidv1pb.Bot {
+	bot := &machineidv1pb.Bot{
+		Kind:    types.KindBot,
+		Version: types.V1,
+		Metadata: &headerv1.Metadata{
+			Name: name,
+		},
+		Spec: &machineidv1pb.BotSpec{
+			Roles: roles,
+		},
+	}
+	if maxSessionTTL != 0 {
+		bot.Spec.MaxSessionTtl = durationpb.New(maxSessionTTL)
+	}
+	return bot
+}
+
+func TestBotCollection_writeText(t *testing.T) {
+	collection := &botCollection{bots: []*machineidv1pb.Bot{
+		newBot("ci", []string{"ci-deploy", "ci-test"}, 12*time.Hour),
+		newBot("scanner", []string{"scanner"}, 0),
+	}}
+
+	table := asciitable.MakeTable(
+		[]string{"Name", "Roles", "Max Session TTL"},
+		[]string{"ci", "ci-deploy, ci-test", "12h0m0s"},
+		[]string{"scanner", "scanner", ""},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
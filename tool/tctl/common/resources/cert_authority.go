@@ -45,8 +45,14 @@ func (a *certAuthorityCollection) Resources() (r []types.Resource) {
 }
 
 func (a *certAuthorityCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"Cluster Name", "CA Type", "Fingerprint", "Role Map"})
+	t := asciitable.MakeTable([]string{"Cluster Name", "CA Type", "Rotation Phase", "Fingerprint", "Role Map"})
 	for _, a := range a.cas {
+		// An empty phase means the CA has never been rotated, which is
+		// equivalent to standby.
+		phase := a.GetRotation().Phase
+		if phase == "" {
+			phase = types.RotationPhaseStandby
+		}
 		for _, key := range a.GetTrustedSSHKeyPairs() {
 			fingerprint, err := sshutils.AuthorizedKeyFingerprint(key.PublicKey)
 			if err != nil {
@@ -61,6 +67,7 @@ func (a *certAuthorityCollection) WriteText(w io.Writer, verbose bool) error {
 			t.AddRow([]string{
 				a.GetClusterName(),
 				string(a.GetType()),
+				phase,
 				fingerprint,
 				roles,
 			})
@@ -41,12 +41,21 @@ func (c *networkingConfigCollection) Resources() (r []types.Resource) {
 }
 
 func (c *networkingConfigCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"Client Idle Timeout", "Keep Alive Interval", "Keep Alive Count Max", "Session Control Timeout"})
+	// The tunnel strategy is only unset on unversioned configs, show an
+	// empty cell instead of failing the whole table.
+	tunnelStrategy := ""
+	if strategyType, err := c.netConfig.GetTunnelStrategyType(); err == nil {
+		tunnelStrategy = string(strategyType)
+	}
+	t := asciitable.MakeTable([]string{"Client Idle Timeout", "Web Idle Timeout", "Keep Alive Interval", "Keep Alive Count Max", "Session Control Timeout", "Tunnel Strategy", "Proxy Ping Interval"})
 	t.AddRow([]string{
 		c.netConfig.GetClientIdleTimeout().String(),
+		c.netConfig.GetWebIdleTimeout().String(),
 		c.netConfig.GetKeepAliveInterval().String(),
 		strconv.FormatInt(c.netConfig.GetKeepAliveCountMax(), 10),
 		c.netConfig.GetSessionControlTimeout().String(),
+		tunnelStrategy,
+		c.netConfig.GetProxyPingInterval().String(),
 	})
 	_, err := t.AsBuffer().WriteTo(w)
 	return trace.Wrap(err)
@@ -0,0 +1,53 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func TestNetworkingConfigCollection_writeText(t *testing.T) {
+	netConfig, err := types.NewClusterNetworkingConfigFromConfigFile(types.ClusterNetworkingConfigSpecV2{
+		ClientIdleTimeout:     types.Duration(30 * time.Minute),
+		WebIdleTimeout:        types.Duration(10 * time.Minute),
+		KeepAliveInterval:     types.Duration(5 * time.Minute),
+		KeepAliveCountMax:     3,
+		SessionControlTimeout: types.Duration(time.Minute),
+		TunnelStrategy: &types.TunnelStrategyV1{
+			Strategy: &types.TunnelStrategyV1_ProxyPeering{
+				ProxyPeering: types.DefaultProxyPeeringTunnelStrategy(),
+			},
+		},
+		ProxyPingInterval: types.Duration(15 * time.Second),
+	})
+	require.NoError(t, err)
+	collection := &networkingConfigCollection{netConfig: netConfig}
+
+	table := asciitable.MakeTable(
+		[]string{"Client Idle Timeout", "Web Idle Timeout", "Keep Alive Interval", "Keep Alive Count Max", "Session Control Timeout", "Tunnel Strategy", "Proxy Ping Interval"},
+		[]string{"30m0s", "10m0s", "5m0s", "3", "1m0s", string(types.ProxyPeering), "15s"},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
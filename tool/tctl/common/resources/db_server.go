@@ -0,0 +1,166 @@
+/*
+ * Teleport
+ * Copyright (C) 2025  Gravitational, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/gravitational/trace"
+
+	apidefaults "github.com/gravitational/teleport/api/defaults"
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+	"github.com/gravitational/teleport/lib/auth/authclient"
+	"github.com/gravitational/teleport/lib/services"
+	"github.com/gravitational/teleport/tool/common"
+)
+
+type dbServerCollection struct {
+	servers []types.DatabaseServer
+}
+
+// NewDatabaseServerCollection creates a [Collection] over the provided
+// database servers.
+func NewDatabaseServerCollection(servers []types.DatabaseServer) Collection {
+	return &dbServerCollection{servers: servers}
+}
+
+func (c *dbServerCollection) Resources() (r []types.Resource) {
+	for _, resource := range c.servers {
+		r = append(r, resource)
+	}
+	return r
+}
+
+func (c *dbServerCollection) WriteText(w io.Writer, verbose bool) error {
+	rows := make([][]string, 0, len(c.servers))
+	for _, server := range c.servers {
+		labels := common.FormatLabels(server.GetDatabase().GetAllLabels(), verbose)
+		rows = append(rows, []string{
+			server.GetHostname(),
+			common.FormatResourceName(server.GetDatabase(), verbose),
+			server.GetDatabase().GetProtocol(),
+			server.GetDatabase().GetURI(),
+			labels,
+			server.GetTeleportVersion(),
+		})
+	}
+	headers := []string{"Host", "Name", "Protocol", "URI", "Labels", "Version"}
+	var t asciitable.Table
+	if verbose {
+		t = asciitable.MakeTable(headers, rows...)
+	} else {
+		t = asciitable.MakeTableWithTruncatedColumn(headers, rows, "Labels")
+	}
+	// stable sort by hostname then by name.
+	t.SortRowsBy([]int{0, 1}, true)
+	_, err := t.AsBuffer().WriteTo(w)
+	return trace.Wrap(err)
+}
+
+func databaseServerHandler() Handler {
+	return Handler{
+		getHandler:    getDatabaseServer,
+		createHandler: createDatabaseServer,
+		updateHandler: updateDatabaseServer,
+		deleteHandler: deleteDatabaseServer,
+		singleton:     false,
+		mfaRequired:   false,
+		description:   "Represents a database access agent proxying a database in the cluster.",
+	}
+}
+
+// databaseServerAltName lets filtering match database agents by the host they
+// registered from, in addition to the database name and discovered name.
+func databaseServerAltName(server types.DatabaseServer) string {
+	return server.GetHostname()
+}
+
+func getDatabaseServer(ctx context.Context, client *authclient.Client, ref services.Ref, opts GetOpts) (Collection, error) {
+	servers, err := client.GetDatabaseServers(ctx, apidefaults.Namespace)
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+	if ref.Name != "" {
+		servers = FilterByNameOrDiscoveredName(servers, ref.Name, databaseServerAltName)
+		if len(servers) == 0 {
+			return nil, trace.NotFound("database server %q not found", ref.Name)
+		}
+	}
+	return &dbServerCollection{servers: servers}, nil
+}
+
+func createDatabaseServer(ctx context.Context, client *authclient.Client, raw services.UnknownResource, opts CreateOpts) error {
+	server, err := services.UnmarshalDatabaseServer(raw.Raw, services.DisallowUnknown())
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	if _, err := client.UpsertDatabaseServer(ctx, server); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("database server %q has been upserted\n", server.GetName())
+	return nil
+}
+
+func updateDatabaseServer(ctx context.Context, client *authclient.Client, raw services.UnknownResource, opts CreateOpts) error {
+	server, err := services.UnmarshalDatabaseServer(raw.Raw, services.DisallowUnknown())
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	// Check if a database server with the same name and host ID exists.
+	servers, err := client.GetDatabaseServers(ctx, apidefaults.Namespace)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	if !slices.ContainsFunc(servers, func(e types.DatabaseServer) bool {
+		return e.GetName() == server.GetName() && e.GetHostID() == server.GetHostID()
+	}) {
+		return trace.NotFound("database server %q with host ID %q not found", server.GetName(), server.GetHostID())
+	}
+
+	if _, err := client.UpsertDatabaseServer(ctx, server); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("database server %q has been updated\n", server.GetName())
+	return nil
+}
+
+func deleteDatabaseServer(ctx context.Context, client *authclient.Client, ref services.Ref) error {
+	servers, err := client.GetDatabaseServers(ctx, apidefaults.Namespace)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	resDesc := "database server"
+	servers = FilterByNameOrDiscoveredName(servers, ref.Name, databaseServerAltName)
+	name, err := GetOneResourceNameToDelete(servers, ref, resDesc)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	for _, s := range servers {
+		if err := client.DeleteDatabaseServer(ctx, apidefaults.Namespace, s.GetHostID(), name); err != nil {
+			return trace.Wrap(err)
+		}
+	}
+	fmt.Printf("%s %q has been deleted\n", resDesc, name)
+	return nil
+}
@@ -0,0 +1,211 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gravitational/trace"
+
+	devicepb "github.com/gravitational/teleport/api/gen/proto/go/teleport/devicetrust/v1"
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+	"github.com/gravitational/teleport/lib/auth/authclient"
+	"github.com/gravitational/teleport/lib/devicetrust"
+	"github.com/gravitational/teleport/lib/services"
+)
+
+type deviceCollection struct {
+	devices []*devicepb.Device
+}
+
+func (c *deviceCollection) Resources() []types.Resource {
+	resources := make([]types.Resource, len(c.devices))
+	for i, dev := range c.devices {
+		resources[i] = types.DeviceToResource(dev)
+	}
+	return resources
+}
+
+func (c *deviceCollection) WriteText(w io.Writer, verbose bool) error {
+	t := asciitable.MakeTable([]string{"ID", "OS Type", "Asset Tag", "Enrollment Status", "Creation Time", "Last Updated"})
+	for _, device := range c.devices {
+		t.AddRow([]string{
+			device.Id,
+			devicetrust.FriendlyOSType(device.OsType),
+			device.AssetTag,
+			devicetrust.FriendlyDeviceEnrollStatus(device.EnrollStatus),
+			device.CreateTime.AsTime().Format(time.RFC3339),
+			device.UpdateTime.AsTime().Format(time.RFC3339),
+		})
+	}
+	_, err := t.AsBuffer().WriteTo(w)
+	return trace.Wrap(err)
+}
+
+func deviceHandler() Handler {
+	return Handler{
+		getHandler:    getDevice,
+		createHandler: createDevice,
+		deleteHandler: deleteDevice,
+		description:   "Represents a trusted device registered for Device Trust.",
+	}
+}
+
+func getDevice(
+	ctx context.Context,
+	client *authclient.Client,
+	ref services.Ref,
+	opts GetOpts,
+) (Collection, error) {
+	remote := client.DevicesClient()
+	if ref.Name != "" {
+		// FindDevices matches on either the device ID or the asset tag, so
+		// both spellings work here.
+		resp, err := remote.FindDevices(ctx, &devicepb.FindDevicesRequest{
+			IdOrTag: ref.Name,
+		})
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+
+		return &deviceCollection{resp.Devices}, nil
+	}
+
+	req := &devicepb.ListDevicesRequest{
+		View: devicepb.DeviceView_DEVICE_VIEW_RESOURCE,
+	}
+	var devs []*devicepb.Device
+	for {
+		resp, err := remote.ListDevices(ctx, req)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+
+		devs = append(devs, resp.Devices...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	sort.Slice(devs, func(i, j int) bool {
+		d1 := devs[i]
+		d2 := devs[j]
+
+		if d1.AssetTag == d2.AssetTag {
+			return d1.OsType < d2.OsType
+		}
+
+		return d1.AssetTag < d2.AssetTag
+	})
+
+	return &deviceCollection{devices: devs}, nil
+}
+
+func createDevice(
+	ctx context.Context,
+	client *authclient.Client,
+	raw services.UnknownResource,
+	opts CreateOpts,
+) error {
+	res, err := services.UnmarshalDevice(raw.Raw)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+	dev, err := types.DeviceFromResource(res)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	if opts.Force {
+		_, err = client.DevicesClient().UpsertDevice(ctx, &devicepb.UpsertDeviceRequest{
+			Device:           dev,
+			CreateAsResource: true,
+		})
+		// err checked below
+	} else {
+		_, err = client.DevicesClient().CreateDevice(ctx, &devicepb.CreateDeviceRequest{
+			Device:           dev,
+			CreateAsResource: true,
+		})
+		// err checked below
+	}
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	verb := "created"
+	if opts.Force {
+		verb = "updated"
+	}
+
+	fmt.Printf("Device %v/%v %v\n",
+		dev.AssetTag,
+		devicetrust.FriendlyOSType(dev.OsType),
+		verb,
+	)
+	return nil
+}
+
+func deleteDevice(
+	ctx context.Context,
+	client *authclient.Client,
+	ref services.Ref,
+) error {
+	remote := client.DevicesClient()
+	device, err := findDeviceByIDOrTag(ctx, remote, ref.Name)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	if _, err := remote.DeleteDevice(ctx, &devicepb.DeleteDeviceRequest{
+		DeviceId: device[0].Id,
+	}); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("Device %q removed\n", ref.Name)
+	return nil
+}
+
+func findDeviceByIDOrTag(ctx context.Context, remote devicepb.DeviceTrustServiceClient, idOrTag string) ([]*devicepb.Device, error) {
+	resp, err := remote.FindDevices(ctx, &devicepb.FindDevicesRequest{
+		IdOrTag: idOrTag,
+	})
+	switch {
+	case err != nil:
+		return nil, trace.Wrap(err)
+	case len(resp.Devices) == 0:
+		return nil, trace.NotFound("device %q not found", idOrTag)
+	case len(resp.Devices) == 1:
+		return resp.Devices, nil
+	}
+
+	// Do we have an ID match?
+	for _, dev := range resp.Devices {
+		if dev.Id == idOrTag {
+			return []*devicepb.Device{dev}, nil
+		}
+	}
+
+	return nil, trace.BadParameter("found multiple devices for asset tag %q, please retry using the device ID instead", idOrTag)
+}
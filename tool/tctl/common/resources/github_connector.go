@@ -22,7 +22,7 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"strings"
+	"strconv"
 
 	"github.com/gravitational/trace"
 
@@ -45,24 +45,17 @@ func (c *githubConnectorCollection) Resources() (r []types.Resource) {
 }
 
 func (c *githubConnectorCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"Name", "Teams To Logins"})
+	t := asciitable.MakeTable([]string{"Name", "Team Mappings", "Endpoint URL"})
 	for _, conn := range c.connectors {
-		t.AddRow([]string{conn.GetName(), formatTeamsToLogins(
-			conn.GetTeamsToLogins())})
+		// Legacy teams_to_logins mappings count as well so connectors that
+		// predate teams_to_roles do not show up as unmapped.
+		mappings := len(conn.GetTeamsToRoles()) + len(conn.GetTeamsToLogins())
+		t.AddRow([]string{conn.GetName(), strconv.Itoa(mappings), conn.GetEndpointURL()})
 	}
 	_, err := t.AsBuffer().WriteTo(w)
 	return trace.Wrap(err)
 }
 
-func formatTeamsToLogins(mappings []types.TeamMapping) string {
-	var result []string
-	for _, m := range mappings {
-		result = append(result, fmt.Sprintf("@%v/%v: %v",
-			m.Organization, m.Team, strings.Join(m.Logins, ", ")))
-	}
-	return strings.Join(result, ", ")
-}
-
 func githubConnectorHandler() Handler {
 	return Handler{
 		getHandler:    getGithubConnector,
@@ -136,6 +129,15 @@ func updateGithubConnector(ctx context.Context, client *authclient.Client, raw s
 		return trace.Wrap(err)
 	}
 
+	// Check the connector exists before updating so the user gets a clear
+	// not-found error instead of whatever the conditional update surfaces.
+	if _, err := client.GetGithubConnector(ctx, connector.GetName(), false); err != nil {
+		if trace.IsNotFound(err) {
+			return trace.NotFound("github connector %q not found", connector.GetName())
+		}
+		return trace.Wrap(err)
+	}
+
 	if _, err := client.UpdateGithubConnector(ctx, connector); err != nil {
 		return trace.Wrap(err)
 	}
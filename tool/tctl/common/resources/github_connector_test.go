@@ -0,0 +1,48 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func TestGithubConnectorCollection_writeText(t *testing.T) {
+	connector, err := types.NewGithubConnector("github", types.GithubConnectorSpecV3{
+		ClientID:     "12345",
+		ClientSecret: "678910",
+		RedirectURL:  "https://proxy.example.com/v1/webapi/github/callback",
+		TeamsToRoles: []types.TeamRolesMapping{
+			{Organization: "acme", Team: "users", Roles: []string{"access"}},
+			{Organization: "acme", Team: "admins", Roles: []string{"access", "editor"}},
+		},
+	})
+	require.NoError(t, err)
+	collection := &githubConnectorCollection{connectors: []types.GithubConnector{connector}}
+
+	table := asciitable.MakeTable(
+		[]string{"Name", "Team Mappings", "Endpoint URL"},
+		[]string{"github", "2", types.GithubURL},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
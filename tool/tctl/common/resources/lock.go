@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/gravitational/trace"
 
@@ -46,14 +47,20 @@ func (c *lockCollection) Resources() []types.Resource {
 }
 
 func (c *lockCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"ID", "Target", "Message", "Expires"})
+	now := time.Now()
+	t := asciitable.MakeTable([]string{"ID", "Target", "Message", "Expires", "Created By"})
 	for _, lock := range c.locks {
 		target := lock.Target()
 		expires := "never"
 		if lock.LockExpiry() != nil {
 			expires = apiutils.HumanTimeFormat(*lock.LockExpiry())
+			// Flag locks that are no longer in force so responders can tell
+			// them apart from active ones at a glance.
+			if !lock.IsInForce(now) {
+				expires += " (expired)"
+			}
 		}
-		t.AddRow([]string{lock.GetName(), target.String(), lock.Message(), expires})
+		t.AddRow([]string{lock.GetName(), target.String(), lock.Message(), expires, lock.CreatedBy()})
 	}
 	_, err := t.AsBuffer().WriteTo(w)
 	return trace.Wrap(err)
@@ -0,0 +1,63 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	apiutils "github.com/gravitational/teleport/api/utils"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func mustCreateNewLock(t *testing.T, name, user, message, createdBy string, expires *time.Time) types.Lock {
+	t.Helper()
+	lock, err := types.NewLock(name, types.LockSpecV2{
+		Target: types.LockTarget{
+			User: user,
+		},
+		Message:   message,
+		Expires:   expires,
+		CreatedBy: createdBy,
+	})
+	require.NoError(t, err)
+	return lock
+}
+
+func TestLockCollection_writeText(t *testing.T) {
+	expired := time.Now().Add(-time.Hour).UTC()
+	active := time.Now().Add(time.Hour).UTC()
+	locks := []types.Lock{
+		mustCreateNewLock(t, "lock-1", "alice", "compromised credentials", "bob", &expired),
+		mustCreateNewLock(t, "lock-2", "carol", "pending investigation", "bob", &active),
+		mustCreateNewLock(t, "lock-3", "dave", "", "security-bot", nil),
+	}
+	collection := &lockCollection{locks: locks}
+
+	table := asciitable.MakeTable(
+		[]string{"ID", "Target", "Message", "Expires", "Created By"},
+		[]string{"lock-1", locks[0].Target().String(), "compromised credentials", apiutils.HumanTimeFormat(expired) + " (expired)", "bob"},
+		[]string{"lock-2", locks[1].Target().String(), "pending investigation", apiutils.HumanTimeFormat(active), "bob"},
+		[]string{"lock-3", locks[2].Target().String(), "", "never", "security-bot"},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
@@ -0,0 +1,170 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/gravitational/trace"
+
+	loginrulepb "github.com/gravitational/teleport/api/gen/proto/go/teleport/loginrule/v1"
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/api/utils/clientutils"
+	"github.com/gravitational/teleport/lib/asciitable"
+	"github.com/gravitational/teleport/lib/auth/authclient"
+	"github.com/gravitational/teleport/lib/itertools/stream"
+	"github.com/gravitational/teleport/lib/services"
+	"github.com/gravitational/teleport/tool/tctl/common/loginrule"
+)
+
+type loginRuleCollection struct {
+	rules []*loginrulepb.LoginRule
+}
+
+func (l *loginRuleCollection) Resources() []types.Resource {
+	resources := make([]types.Resource, len(l.rules))
+	for i, rule := range l.rules {
+		resources[i] = loginrule.ProtoToResource(rule)
+	}
+	return resources
+}
+
+func (l *loginRuleCollection) WriteText(w io.Writer, verbose bool) error {
+	t := asciitable.MakeTable([]string{"Name", "Priority", "Traits"})
+	for _, rule := range l.rules {
+		t.AddRow([]string{
+			rule.Metadata.Name,
+			strconv.FormatInt(int64(rule.Priority), 10),
+			strconv.Itoa(len(rule.TraitsMap)),
+		})
+	}
+	_, err := t.AsBuffer().WriteTo(w)
+	return trace.Wrap(err)
+}
+
+func loginRuleHandler() Handler {
+	return Handler{
+		getHandler:    getLoginRule,
+		createHandler: createLoginRule,
+		updateHandler: updateLoginRule,
+		deleteHandler: deleteLoginRule,
+		description:   "Evaluates expressions to set the traits of users as they log in.",
+	}
+}
+
+func getLoginRule(
+	ctx context.Context,
+	client *authclient.Client,
+	ref services.Ref,
+	opts GetOpts,
+) (Collection, error) {
+	c := client.LoginRuleClient()
+	if ref.Name != "" {
+		rule, err := c.GetLoginRule(ctx, &loginrulepb.GetLoginRuleRequest{
+			Name: ref.Name,
+		})
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+
+		return &loginRuleCollection{rules: []*loginrulepb.LoginRule{rule}}, nil
+	}
+
+	rules, err := stream.Collect(clientutils.Resources(ctx, func(ctx context.Context, limit int, token string) ([]*loginrulepb.LoginRule, string, error) {
+		resp, err := c.ListLoginRules(ctx, &loginrulepb.ListLoginRulesRequest{
+			PageSize:  int32(limit),
+			PageToken: token,
+		})
+
+		return resp.GetLoginRules(), resp.GetNextPageToken(), trace.Wrap(err)
+	}))
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+
+	return &loginRuleCollection{rules: rules}, nil
+}
+
+func createLoginRule(
+	ctx context.Context,
+	client *authclient.Client,
+	raw services.UnknownResource,
+	opts CreateOpts,
+) error {
+	rule, err := loginrule.UnmarshalLoginRule(raw.Raw)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	c := client.LoginRuleClient()
+	if opts.Force {
+		if _, err := c.UpsertLoginRule(ctx, &loginrulepb.UpsertLoginRuleRequest{
+			LoginRule: rule,
+		}); err != nil {
+			return trace.Wrap(err)
+		}
+		fmt.Printf("login rule %q has been upserted\n", rule.Metadata.Name)
+		return nil
+	}
+
+	if _, err := c.CreateLoginRule(ctx, &loginrulepb.CreateLoginRuleRequest{
+		LoginRule: rule,
+	}); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("login rule %q has been created\n", rule.Metadata.Name)
+	return nil
+}
+
+func updateLoginRule(
+	ctx context.Context,
+	client *authclient.Client,
+	raw services.UnknownResource,
+	opts CreateOpts,
+) error {
+	rule, err := loginrule.UnmarshalLoginRule(raw.Raw)
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	if _, err := client.LoginRuleClient().UpsertLoginRule(ctx, &loginrulepb.UpsertLoginRuleRequest{
+		LoginRule: rule,
+	}); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("login rule %q has been updated\n", rule.Metadata.Name)
+	return nil
+}
+
+// deleteLoginRule deletes the rule with the exact given name. Login rules are
+// not host-bound, so there is no discovered-name fallback here.
+func deleteLoginRule(
+	ctx context.Context,
+	client *authclient.Client,
+	ref services.Ref,
+) error {
+	if _, err := client.LoginRuleClient().DeleteLoginRule(ctx, &loginrulepb.DeleteLoginRuleRequest{
+		Name: ref.Name,
+	}); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("login rule %q has been deleted\n", ref.Name)
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Teleport
+ * Copyright (C) 2026  Gravitational, Inc.
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gravitational/trace"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+	"github.com/gravitational/teleport/lib/auth/authclient"
+	"github.com/gravitational/teleport/lib/services"
+)
+
+type networkRestrictionsCollection struct {
+	netRestricts types.NetworkRestrictions
+}
+
+func (c *networkRestrictionsCollection) Resources() []types.Resource {
+	return []types.Resource{c.netRestricts}
+}
+
+func (c *networkRestrictionsCollection) WriteText(w io.Writer, verbose bool) error {
+	t := asciitable.MakeTable([]string{"Allow CIDRs", "Deny CIDRs"})
+	t.AddRow([]string{
+		formatCIDRList(c.netRestricts.GetAllow(), verbose),
+		formatCIDRList(c.netRestricts.GetDeny(), verbose),
+	})
+	_, err := t.AsBuffer().WriteTo(w)
+	return trace.Wrap(err)
+}
+
+// formatCIDRList renders the CIDRs of an allow or deny list. The lists can
+// grow very long, so the non-verbose form only counts them; pass --verbose to
+// see every entry.
+func formatCIDRList(conditions []types.AddressCondition, verbose bool) string {
+	if verbose {
+		cidrs := make([]string, 0, len(conditions))
+		for _, condition := range conditions {
+			cidrs = append(cidrs, condition.CIDR)
+		}
+		return strings.Join(cidrs, ",")
+	}
+	return strconv.Itoa(len(conditions))
+}
+
+func networkRestrictionsHandler() Handler {
+	return Handler{
+		getHandler:    getNetworkRestrictions,
+		createHandler: createNetworkRestrictions,
+		updateHandler: updateNetworkRestrictions,
+		deleteHandler: deleteNetworkRestrictions,
+		singleton:     true,
+		mfaRequired:   false,
+		description:   "Restricts the addresses that outbound connections from restricted shell sessions may reach.",
+	}
+}
+
+func getNetworkRestrictions(ctx context.Context, client *authclient.Client, ref services.Ref, opts GetOpts) (Collection, error) {
+	if ref.Name != "" {
+		return nil, trace.BadParameter("only simple `tctl get %v` can be used", types.KindNetworkRestrictions)
+	}
+	netRestricts, err := client.GetNetworkRestrictions(ctx)
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+	return &networkRestrictionsCollection{netRestricts}, nil
+}
+
+// createNetworkRestrictions implements `tctl create net_restrict.yaml` command.
+func createNetworkRestrictions(ctx context.Context, client *authclient.Client, raw services.UnknownResource, opts CreateOpts) error {
+	newNetRestricts, err := services.UnmarshalNetworkRestrictions(raw.Raw, services.DisallowUnknown())
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	if err := client.SetNetworkRestrictions(ctx, newNetRestricts); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("network restrictions have been updated\n")
+	return nil
+}
+
+func updateNetworkRestrictions(ctx context.Context, client *authclient.Client, raw services.UnknownResource, opts CreateOpts) error {
+	newNetRestricts, err := services.UnmarshalNetworkRestrictions(raw.Raw, services.DisallowUnknown())
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	// The backend has a single set RPC for the singleton, so updating only
+	// differs from creating in that it requires the resource to exist.
+	if _, err := client.GetNetworkRestrictions(ctx); err != nil {
+		return trace.Wrap(err)
+	}
+	if err := client.SetNetworkRestrictions(ctx, newNetRestricts); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("network restrictions have been updated\n")
+	return nil
+}
+
+func deleteNetworkRestrictions(ctx context.Context, client *authclient.Client, ref services.Ref) error {
+	if err := client.DeleteNetworkRestrictions(ctx); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("network restrictions have been reset to defaults (allow all)\n")
+	return nil
+}
@@ -0,0 +1,46 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func TestNetworkRestrictionsCollection_writeText(t *testing.T) {
+	netRestricts := types.NewNetworkRestrictions()
+	netRestricts.SetAllow([]types.AddressCondition{
+		{CIDR: "10.0.0.0/8"},
+		{CIDR: "192.168.1.0/24"},
+	})
+	netRestricts.SetDeny([]types.AddressCondition{
+		{CIDR: "10.1.2.3/32"},
+	})
+	collection := &networkRestrictionsCollection{netRestricts: netRestricts}
+
+	headers := []string{"Allow CIDRs", "Deny CIDRs"}
+	verboseTable := asciitable.MakeTable(headers,
+		[]string{"10.0.0.0/8,192.168.1.0/24", "10.1.2.3/32"})
+	// The non-verbose form only counts the entries of each list.
+	nonVerboseTable := asciitable.MakeTable(headers, []string{"2", "1"})
+
+	collectionFormatTest(t, collection,
+		verboseTable.AsBuffer().String(),
+		nonVerboseTable.AsBuffer().String())
+}
@@ -45,10 +45,10 @@ func (c *oidcConnectorCollection) Resources() (r []types.Resource) {
 }
 
 func (c *oidcConnectorCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"Name", "Issuer URL", "Additional Scope"})
+	t := asciitable.MakeTable([]string{"Name", "Issuer URL", "Client ID", "Additional Scope"})
 	for _, conn := range c.connectors {
 		t.AddRow([]string{
-			conn.GetName(), conn.GetIssuerURL(), strings.Join(conn.GetScope(), ","),
+			conn.GetName(), conn.GetIssuerURL(), conn.GetClientID(), strings.Join(conn.GetScope(), ","),
 		})
 	}
 	_, err := t.AsBuffer().WriteTo(w)
@@ -0,0 +1,49 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func TestOIDCConnectorCollection_writeText(t *testing.T) {
+	connector, err := types.NewOIDCConnector("oidc", types.OIDCConnectorSpecV3{
+		IssuerURL:    "https://idp.example.com",
+		ClientID:     "12345",
+		ClientSecret: "678910",
+		RedirectURLs: []string{"https://proxy.example.com/v1/webapi/oidc/callback"},
+		Scope:        []string{"groups", "email"},
+		ClaimsToRoles: []types.ClaimMapping{
+			{Claim: "groups", Value: "admin", Roles: []string{"access"}},
+		},
+	})
+	require.NoError(t, err)
+	collection := &oidcConnectorCollection{connectors: []types.OIDCConnector{connector}}
+
+	table := asciitable.MakeTable(
+		[]string{"Name", "Issuer URL", "Client ID", "Additional Scope"},
+		[]string{"oidc", "https://idp.example.com", "12345", "groups,email"},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
@@ -0,0 +1,57 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+
+	headerv1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/header/v1"
+	presencev1 "github.com/gravitational/teleport/api/gen/proto/go/teleport/presence/v1"
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func newRelayServer(name, hostname, relayGroup, peerAddr string) *presencev1.RelayServer {
+	return &presencev1.RelayServer{
+		Kind:    types.KindRelayServer,
+		Version: types.V1,
+		Metadata: &headerv1.Metadata{
+			Name: name,
+		},
+		Spec: &presencev1.RelayServer_Spec{
+			Hostname:   hostname,
+			RelayGroup: relayGroup,
+			PeerAddr:   peerAddr,
+		},
+	}
+}
+
+func TestRelayServerCollection_writeText(t *testing.T) {
+	collection := &relayServerCollection{relayServers: []*presencev1.RelayServer{
+		newRelayServer("relay-1", "relay1.example.com", "main", "10.0.0.1:3024"),
+		newRelayServer("relay-2", "relay2.example.com", "main", "10.0.0.2:3024"),
+	}}
+
+	table := asciitable.MakeTable(
+		[]string{"Name", "Hostname", "Relay Group", "Peer Address"},
+		[]string{"relay-1", "relay1.example.com", "main", "10.0.0.1:3024"},
+		[]string{"relay-2", "relay2.example.com", "main", "10.0.0.2:3024"},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
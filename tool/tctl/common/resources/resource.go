@@ -57,6 +57,8 @@ func Handlers() map[string]Handler {
 		types.KindDatabase:                           databaseHandler(),
 		types.KindDatabaseObject:                     databaseObjectHandler(),
 		types.KindDatabaseObjectImportRule:           databaseObjectImportRuleHandler(),
+		types.KindDatabaseServer:                     databaseServerHandler(),
+		types.KindDevice:                             deviceHandler(),
 		types.KindDiscoveryConfig:                    discoveryConfigHandler(),
 		types.KindDynamicWindowsDesktop:              dynamicWindowsDesktopHandler(),
 		types.KindGithubConnector:                    githubConnectorHandler(),
@@ -65,6 +67,8 @@ func Handlers() map[string]Handler {
 		types.KindInferenceSecret:                    inferenceSecretHandler(),
 		types.KindInstaller:                          installerHandler(),
 		types.KindLock:                               lockHandler(),
+		types.KindLoginRule:                          loginRuleHandler(),
+		types.KindNetworkRestrictions:                networkRestrictionsHandler(),
 		types.KindNode:                               serverHandler(),
 		types.KindOIDCConnector:                      oidcConnectorHandler(),
 		types.KindProxy:                              proxyHandler(),
@@ -78,6 +82,7 @@ func Handlers() map[string]Handler {
 		types.KindSPIFFEFederation:                   spiffeFederationHandler(),
 		types.KindStaticHostUser:                     staticHostUserHandler(),
 		types.KindToken:                              tokenHandler(),
+		types.KindTrustedCluster:                     trustedClusterHandler(),
 		types.KindUIConfig:                           uiConfigHandler(),
 		types.KindUser:                               userHandler(),
 		types.KindUserTask:                           userTasksHandler(),
@@ -109,6 +114,17 @@ type Handler struct {
 type GetOpts struct {
 	// WithSecrets is true if the user set --with-secrets
 	WithSecrets bool
+	// Labels is the label selector the user set via --labels, a
+	// comma-separated list of key=value or key!=value predicates. Only some
+	// resource kinds support it.
+	Labels string
+	// Where is a predicate-language expression the user set via --query,
+	// evaluated against each resource. Only some resource kinds support it.
+	Where string
+	// PrefixMatch is true if the user set --prefix. Name filters then match
+	// resources whose name starts with the requested name instead of
+	// requiring an exact match. Only some resource kinds support it.
+	PrefixMatch bool
 }
 
 // CreateOpts contains the possible options when creating/updating a resource.
@@ -117,6 +133,10 @@ type CreateOpts struct {
 	Force bool
 	// Confirm is true if the user set --Confirm
 	Confirm bool
+	// DryRun is true if the user set --dry-run. Handlers that support it
+	// run their unmarshal and validation checks but skip the mutating
+	// call, reporting what would have happened.
+	DryRun bool
 }
 
 // Get queries the cluster to get the desired resource and returns a Collection.
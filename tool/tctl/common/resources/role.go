@@ -55,18 +55,26 @@ func (r *roleCollection) WriteText(w io.Writer, verbose bool) error {
 		if r.GetName() == constants.DefaultImplicitRole {
 			continue
 		}
-		rows = append(rows, []string{
+		row := []string{
 			r.GetMetadata().Name,
 			strings.Join(r.GetLogins(types.Allow), ","),
+			fmt.Sprintf("%d/%d", len(r.GetRules(types.Allow)), len(r.GetRules(types.Deny))),
 			printNodeLabels(r.GetNodeLabels(types.Allow)),
 			printActions(r.GetRules(types.Allow)),
-		})
+		}
+		if verbose {
+			row = append(row,
+				printLabels(r.GetKubernetesLabels(types.Allow), "<all kube clusters>"),
+				printLabels(r.GetDatabaseLabels(types.Allow), "<all databases>"),
+			)
+		}
+		rows = append(rows, row)
 	}
 
-	headers := []string{"Role", "Allowed to login as", "Node Labels", "Access to resources"}
+	headers := []string{"Role", "Allowed to login as", "Rules (Allow/Deny)", "Node Labels", "Access to resources"}
 	var t asciitable.Table
 	if verbose {
-		t = asciitable.MakeTable(headers, rows...)
+		t = asciitable.MakeTable(append(headers, "Kube Labels", "DB Labels"), rows...)
 	} else {
 		t = asciitable.MakeTableWithTruncatedColumn(headers, rows, "Access to resources")
 	}
@@ -84,10 +92,16 @@ func printActions(rules []types.Rule) string {
 }
 
 func printNodeLabels(labels types.Labels) string {
+	return printLabels(labels, "<all nodes>")
+}
+
+// printLabels formats a label selector, collapsing a wildcard selector to the
+// provided placeholder.
+func printLabels(labels types.Labels, wildcard string) string {
 	pairs := []string{}
 	for key, values := range labels {
 		if key == types.Wildcard {
-			return "<all nodes>"
+			return wildcard
 		}
 		pairs = append(pairs, fmt.Sprintf("%v=%v", key, values))
 	}
@@ -0,0 +1,59 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func TestRoleCollection_writeText(t *testing.T) {
+	role, err := types.NewRole("dev", types.RoleSpecV6{
+		Allow: types.RoleConditions{
+			Logins:     []string{"dev", "ubuntu"},
+			NodeLabels: types.Labels{"env": []string{"dev"}},
+			KubernetesLabels: types.Labels{
+				types.Wildcard: []string{types.Wildcard},
+			},
+			DatabaseLabels: types.Labels{"env": []string{"dev"}},
+			Rules: []types.Rule{
+				{Resources: []string{types.KindSession}, Verbs: []string{types.VerbRead, types.VerbList}},
+			},
+		},
+		Deny: types.RoleConditions{
+			Rules: []types.Rule{
+				{Resources: []string{types.KindRole}, Verbs: []string{types.Wildcard}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	collection := &roleCollection{roles: []types.Role{role}}
+
+	headers := []string{"Role", "Allowed to login as", "Rules (Allow/Deny)", "Node Labels", "Access to resources"}
+	row := []string{"dev", "dev,ubuntu", "1/1", "env=[dev]", "session:read,list"}
+	verboseTable := asciitable.MakeTable(append(headers, "Kube Labels", "DB Labels"),
+		append(row, "<all kube clusters>", "env=[dev]"))
+	nonVerboseTable := asciitable.MakeTableWithTruncatedColumn(headers, [][]string{row}, "Access to resources")
+
+	collectionFormatTest(t, collection,
+		verboseTable.AsBuffer().String(),
+		nonVerboseTable.AsBuffer().String())
+}
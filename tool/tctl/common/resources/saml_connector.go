@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 
 	"github.com/gravitational/trace"
 
@@ -44,9 +45,13 @@ func (c *samlConnectorCollection) Resources() (r []types.Resource) {
 }
 
 func (c *samlConnectorCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"Name", "SSO URL"})
+	t := asciitable.MakeTable([]string{"Name", "SSO URL", "Attribute Mappings"})
 	for _, conn := range c.connectors {
-		t.AddRow([]string{conn.GetName(), conn.GetSSO()})
+		t.AddRow([]string{
+			conn.GetName(),
+			conn.GetSSO(),
+			strconv.Itoa(len(conn.GetAttributesToRoles())),
+		})
 	}
 	_, err := t.AsBuffer().WriteTo(w)
 	return trace.Wrap(err)
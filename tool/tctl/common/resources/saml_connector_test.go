@@ -0,0 +1,48 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func TestSAMLConnectorCollection_writeText(t *testing.T) {
+	connector, err := types.NewSAMLConnector("okta", types.SAMLConnectorSpecV2{
+		AssertionConsumerService: "https://proxy.example.com/v1/webapi/saml/acs",
+		Issuer:                   "test",
+		SSO:                      "https://idp.example.com/sso",
+		AttributesToRoles: []types.AttributeMapping{
+			{Name: "groups", Value: "admin", Roles: []string{"access"}},
+			{Name: "groups", Value: "auditors", Roles: []string{"auditor"}},
+		},
+	})
+	require.NoError(t, err)
+	collection := &samlConnectorCollection{connectors: []types.SAMLConnector{connector}}
+
+	table := asciitable.MakeTable(
+		[]string{"Name", "SSO URL", "Attribute Mappings"},
+		[]string{"okta", "https://idp.example.com/sso", "2"},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
@@ -0,0 +1,65 @@
+// Teleport
+// Copyright (C) 2025 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+	"github.com/gravitational/teleport/tool/common"
+)
+
+func mustCreateNewServer(t *testing.T, name, hostname, addr string, labels map[string]string) types.Server {
+	t.Helper()
+	server, err := types.NewServerWithLabels(name, types.KindNode, types.ServerSpecV2{
+		Hostname: hostname,
+		Addr:     addr,
+		Version:  "10.1.0",
+	}, labels)
+	require.NoError(t, err)
+	return server
+}
+
+func TestServerCollection_writeText(t *testing.T) {
+	labels := map[string]string{"env": "prod"}
+	servers := []types.Server{
+		mustCreateNewServer(t, "6e4ac4a5-c54b-4518-b1e3-d859a8f31b68", "node1", "127.0.0.1:3022", labels),
+		mustCreateNewServer(t, "da9a2c62-3f21-4c5c-9b3c-9c4a80aadd79", "node2", "127.0.0.2:3022", nil),
+	}
+
+	formattedLabels := common.FormatLabels(labels, false)
+	table := asciitable.MakeTableWithTruncatedColumn(
+		[]string{"Host", "UUID", "Public Address", "Labels", "Version"},
+		[][]string{
+			{"node1", "6e4ac4a5-c54b-4518-b1e3-d859a8f31b68", "127.0.0.1:3022", formattedLabels, "10.1.0"},
+			{"node2", "da9a2c62-3f21-4c5c-9b3c-9c4a80aadd79", "127.0.0.2:3022", "", "10.1.0"},
+		},
+		"Labels")
+	nonVerbose := table.AsBuffer().String()
+
+	verboseTable := asciitable.MakeTable(
+		[]string{"Host", "UUID", "Public Address", "Labels", "Version"},
+		[]string{"node1", "6e4ac4a5-c54b-4518-b1e3-d859a8f31b68", "127.0.0.1:3022", common.FormatLabels(labels, true), "10.1.0"},
+		[]string{"node2", "da9a2c62-3f21-4c5c-9b3c-9c4a80aadd79", "127.0.0.2:3022", "", "10.1.0"},
+	)
+	verbose := verboseTable.AsBuffer().String()
+
+	collectionFormatTest(t, NewServerCollection(servers), verbose, nonVerbose)
+}
@@ -126,6 +126,6 @@ func deleteSessionRecordingConfig(ctx context.Context, client *authclient.Client
 	if err := client.ResetSessionRecordingConfig(ctx); err != nil {
 		return trace.Wrap(err)
 	}
-	fmt.Printf("cluster networking configuration has been reset to defaults\n")
+	fmt.Printf("session recording configuration has been reset to defaults\n")
 	return nil
 }
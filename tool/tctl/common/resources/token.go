@@ -24,7 +24,9 @@ import (
 	"github.com/gravitational/trace"
 
 	"github.com/gravitational/teleport/api/types"
+	apiutils "github.com/gravitational/teleport/api/utils"
 	"github.com/gravitational/teleport/api/utils/clientutils"
+	"github.com/gravitational/teleport/lib/asciitable"
 	"github.com/gravitational/teleport/lib/auth/authclient"
 	"github.com/gravitational/teleport/lib/itertools/stream"
 	services "github.com/gravitational/teleport/lib/services"
@@ -43,12 +45,23 @@ func (c *tokenCollection) Resources() []types.Resource {
 }
 
 func (c *tokenCollection) WriteText(w io.Writer, verbose bool) error {
+	t := asciitable.MakeTable([]string{"Token", "Roles", "Join Method", "Expiry"})
 	for _, token := range c.tokens {
-		if _, err := w.Write([]byte(token.String())); err != nil {
-			return trace.Wrap(err)
+		expiry := "never"
+		if !token.Expiry().IsZero() {
+			expiry = apiutils.HumanTimeFormat(token.Expiry())
 		}
+		t.AddRow([]string{
+			// GetSafeName redacts all but a short suffix of secret token
+			// names, leaving enough of a fingerprint to identify the token.
+			token.GetSafeName(),
+			token.GetRoles().String(),
+			string(token.GetJoinMethod()),
+			expiry,
+		})
 	}
-	return nil
+	_, err := t.AsBuffer().WriteTo(w)
+	return trace.Wrap(err)
 }
 
 func tokenHandler() Handler {
@@ -63,18 +76,29 @@ func tokenHandler() Handler {
 }
 
 func getToken(ctx context.Context, client *authclient.Client, ref services.Ref, opts GetOpts) (Collection, error) {
+	var tokens []types.ProvisionToken
 	if ref.Name == "" {
-		tokens, err := GetAllTokens(ctx, client)
+		var err error
+		tokens, err = GetAllTokens(ctx, client)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+	} else {
+		token, err := client.GetToken(ctx, ref.Name)
 		if err != nil {
 			return nil, trace.Wrap(err)
 		}
-		return &tokenCollection{tokens: tokens}, nil
+		tokens = []types.ProvisionToken{token}
 	}
-	token, err := client.GetToken(ctx, ref.Name)
-	if err != nil {
-		return nil, trace.Wrap(err)
+	// The name of a token-method token is itself the shared join secret, so
+	// redact it from every output format unless secrets were explicitly
+	// requested, keeping only the fingerprint suffix from GetSafeName.
+	if !opts.WithSecrets {
+		for _, token := range tokens {
+			token.SetName(token.GetSafeName())
+		}
 	}
-	return &tokenCollection{tokens: []types.ProvisionToken{token}}, nil
+	return &tokenCollection{tokens: tokens}, nil
 }
 
 // GetAllTokens is a helper that retrieves all kinds of tokens.
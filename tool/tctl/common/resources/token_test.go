@@ -0,0 +1,57 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	apiutils "github.com/gravitational/teleport/api/utils"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func TestTokenCollection_writeText(t *testing.T) {
+	expires := time.Now().Add(time.Hour).UTC()
+	secretToken, err := types.NewProvisionToken(
+		"supersecrettokenvalue123", types.SystemRoles{types.RoleNode}, time.Time{})
+	require.NoError(t, err)
+	iamToken, err := types.NewProvisionTokenFromSpec("iam-token", expires, types.ProvisionTokenSpecV2{
+		Roles:      types.SystemRoles{types.RoleNode, types.RoleProxy},
+		JoinMethod: types.JoinMethodIAM,
+		Allow:      []*types.TokenRule{{AWSAccount: "123456789012"}},
+	})
+	require.NoError(t, err)
+	collection := &tokenCollection{tokens: []types.ProvisionToken{secretToken, iamToken}}
+
+	// Secret token names are redacted down to a fingerprint suffix.
+	safeName := secretToken.GetSafeName()
+	require.NotEqual(t, secretToken.GetName(), safeName)
+	require.True(t, strings.HasPrefix(safeName, "*"))
+
+	table := asciitable.MakeTable(
+		[]string{"Token", "Roles", "Join Method", "Expiry"},
+		[]string{safeName, "Node", string(types.JoinMethodToken), "never"},
+		[]string{"iam-token", "Node,Proxy", string(types.JoinMethodIAM), apiutils.HumanTimeFormat(expires)},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
@@ -0,0 +1,64 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func mustCreateNewTrustedCluster(t *testing.T, name, token string) types.TrustedCluster {
+	t.Helper()
+	tc, err := types.NewTrustedCluster(name, types.TrustedClusterSpecV2{
+		Enabled:              true,
+		Token:                token,
+		ProxyAddress:         name + ":3080",
+		ReverseTunnelAddress: name + ":3024",
+		RoleMap: []types.RoleMapping{
+			{Remote: "admin", Local: []string{"admin"}},
+		},
+	})
+	require.NoError(t, err)
+	return tc
+}
+
+func TestTrustedClusterCollection_writeText(t *testing.T) {
+	// The get handler blanks the token unless secrets were requested, so the
+	// empty column here mirrors what a plain tctl get prints.
+	tc := mustCreateNewTrustedCluster(t, "root.example.com", "")
+	collection := &trustedClusterCollection{trustedClusters: []types.TrustedCluster{tc}}
+
+	table := asciitable.MakeTable(
+		[]string{"Name", "Enabled", "Token", "Proxy Address", "Reverse Tunnel Address", "Role Map"},
+		[]string{
+			"root.example.com",
+			"true",
+			"",
+			"root.example.com:3080",
+			"root.example.com:3024",
+			fmt.Sprintf("%v", tc.CombinedMapping()),
+		},
+	)
+	want := table.AsBuffer().String()
+
+	collectionFormatTest(t, collection, want, want)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/gravitational/trace"
 
@@ -47,12 +48,24 @@ func (u *userCollection) Resources() []types.Resource {
 }
 
 func (u *userCollection) WriteText(w io.Writer, verbose bool) error {
-	t := asciitable.MakeTable([]string{"User"})
+	headers := []string{"User", "Roles", "Auth Type", "Created By"}
+	var rows [][]string
 	for _, user := range u.users {
-		t.AddRow([]string{user.GetName()})
+		rows = append(rows, []string{
+			user.GetName(),
+			strings.Join(user.GetRoles(), ","),
+			string(user.GetUserType()),
+			user.GetCreatedBy().User.Name,
+		})
 	}
-	fmt.Println(t.AsBuffer().String())
-	return nil
+	var t asciitable.Table
+	if verbose {
+		t = asciitable.MakeTable(headers, rows...)
+	} else {
+		t = asciitable.MakeTableWithTruncatedColumn(headers, rows, "Roles")
+	}
+	_, err := t.AsBuffer().WriteTo(w)
+	return trace.Wrap(err)
 }
 
 func userHandler() Handler {
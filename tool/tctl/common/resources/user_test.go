@@ -0,0 +1,54 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+)
+
+func TestUserCollection_writeText(t *testing.T) {
+	localUser, err := types.NewUser("alice")
+	require.NoError(t, err)
+	localUser.SetRoles([]string{"access", "editor"})
+	localUser.SetCreatedBy(types.CreatedBy{User: types.UserRef{Name: "admin"}})
+
+	ssoUser, err := types.NewUser("bob")
+	require.NoError(t, err)
+	ssoUser.SetRoles([]string{"access"})
+	ssoUser.SetCreatedBy(types.CreatedBy{
+		Connector: &types.ConnectorRef{Type: "saml", ID: "okta"},
+		User:      types.UserRef{Name: "system"},
+	})
+	collection := &userCollection{users: []types.User{localUser, ssoUser}}
+
+	headers := []string{"User", "Roles", "Auth Type", "Created By"}
+	rows := [][]string{
+		{"alice", "access,editor", string(localUser.GetUserType()), "admin"},
+		{"bob", "access", string(ssoUser.GetUserType()), "system"},
+	}
+	verboseTable := asciitable.MakeTable(headers, rows...)
+	nonVerboseTable := asciitable.MakeTableWithTruncatedColumn(headers, rows, "Roles")
+
+	collectionFormatTest(t, collection,
+		verboseTable.AsBuffer().String(),
+		nonVerboseTable.AsBuffer().String())
+}
@@ -67,6 +67,32 @@ func FilterByNameOrDiscoveredName[T types.ResourceWithLabels](resources []T, pre
 	return out
 }
 
+// FilterByNameOrDiscoveredNamePrefix is the prefix-matching variant of
+// [FilterByNameOrDiscoveredName], for when an operator only remembers the
+// start of a long auto-generated name. Like the exact variant, name matches
+// are preferred: the "discovered name" label is only consulted when no
+// resource name matches the prefix.
+func FilterByNameOrDiscoveredNamePrefix[T types.ResourceWithLabels](resources []T, prefix string, extra ...AltResourceNameFunc[T]) []T {
+	out := filterResources(resources, func(r T) bool {
+		if strings.HasPrefix(r.GetName(), prefix) {
+			return true
+		}
+		for _, altName := range extra {
+			if strings.HasPrefix(altName(r), prefix) {
+				return true
+			}
+		}
+		return false
+	})
+	if len(out) == 0 {
+		out = filterResources(resources, func(r T) bool {
+			discoveredName, ok := r.GetLabel(types.DiscoveredNameLabel)
+			return ok && strings.HasPrefix(discoveredName, prefix)
+		})
+	}
+	return out
+}
+
 // filterResourcesByName filters resources by exact name match.
 func filterResourcesByName[T types.ResourceWithLabels](resources []T, name string, altNameFns ...AltResourceNameFunc[T]) []T {
 	return filterResources(resources, func(r T) bool {
@@ -95,6 +121,60 @@ func filterResources[T types.ResourceWithLabels](resources []T, keepFn func(T) b
 	return slices.Collect(iterutils.Filter(keepFn, slices.Values(resources)))
 }
 
+// labelPredicate is a single parsed term of a label selector.
+type labelPredicate struct {
+	key     string
+	value   string
+	negated bool
+}
+
+// FilterByLabelSelector filters resources by a label selector consisting of
+// comma-separated key=value and key!=value predicates matched against the
+// resources' combined static and dynamic labels. All predicates must hold
+// for a resource to be kept.
+func FilterByLabelSelector[T types.ResourceWithLabels](resources []T, selector string) ([]T, error) {
+	predicates, err := parseLabelSelector(selector)
+	if err != nil {
+		return nil, trace.Wrap(err)
+	}
+	return filterResources(resources, func(r T) bool {
+		labels := r.GetAllLabels()
+		for _, p := range predicates {
+			value, ok := labels[p.key]
+			if matches := ok && value == p.value; matches == p.negated {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+// parseLabelSelector parses a comma-separated list of key=value and
+// key!=value predicates.
+func parseLabelSelector(selector string) ([]labelPredicate, error) {
+	var predicates []labelPredicate
+	for term := range strings.SplitSeq(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		var p labelPredicate
+		if key, value, ok := strings.Cut(term, "!="); ok {
+			p = labelPredicate{key: key, value: value, negated: true}
+		} else if key, value, ok := strings.Cut(term, "="); ok {
+			p = labelPredicate{key: key, value: value}
+		} else {
+			return nil, trace.BadParameter("unsupported label predicate %q, expected key=value or key!=value", term)
+		}
+		p.key, p.value = strings.TrimSpace(p.key), strings.TrimSpace(p.value)
+		if p.key == "" {
+			return nil, trace.BadParameter("label predicate %q is missing a key", term)
+		}
+		predicates = append(predicates, p)
+	}
+	return predicates, nil
+}
+
 // GetOneResourceNameToDelete checks a list of resources to ensure there is
 // exactly one resource name among them, and returns that name or an error.
 // Heartbeat resources can have the same name but different host ID, so this
@@ -112,28 +192,42 @@ func GetOneResourceNameToDelete[T types.ResourceWithLabels](rs []T, ref services
 		return "", trace.NotFound("%v %q not found", resDesc, ref.Name)
 	default:
 		names := make([]string, 0, len(rs))
+		discoveredNames := make(map[string]string)
 		for _, r := range rs {
-			names = append(names, r.GetName())
+			name := r.GetName()
+			names = append(names, name)
+			if discovered, ok := r.GetLabel(types.DiscoveredNameLabel); ok && discovered != name {
+				discoveredNames[name] = discovered
+			}
 		}
-		msg := formatAmbiguousDeleteMessage(ref, resDesc, names)
+		msg := formatAmbiguousDeleteMessage(ref, resDesc, names, discoveredNames)
 		return "", trace.BadParameter("%s", msg)
 	}
 }
 
 // formatAmbiguousDeleteMessage returns a formatted message when a user is
 // attempting to delete multiple resources by an ambiguous prefix of the
-// resource names.
-func formatAmbiguousDeleteMessage(ref services.Ref, resDesc string, names []string) string {
+// resource names. Names that differ from the cloud resource name they were
+// discovered under are annotated with that discovered name, so operators can
+// recognize which resource they are about to delete.
+func formatAmbiguousDeleteMessage(ref services.Ref, resDesc string, names []string, discoveredNames map[string]string) string {
 	slices.Sort(names)
 	// choose an actual resource for the example in the error.
 	exampleRef := ref
 	exampleRef.Name = names[0]
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		if discovered, ok := discoveredNames[name]; ok {
+			name = fmt.Sprintf("%s (discovered as %q)", name, discovered)
+		}
+		lines = append(lines, name)
+	}
 	return fmt.Sprintf(`%s matches multiple auto-discovered %vs:
 %v
 
 Use the full resource name that was generated by the Teleport Discovery service, for example:
 $ tctl rm %s`,
-		ref.String(), resDesc, strings.Join(names, "\n"), exampleRef.String())
+		ref.String(), resDesc, strings.Join(lines, "\n"), exampleRef.String())
 }
 
 // makeNamePredicate makes a predicate expression that can be used for
@@ -145,3 +239,20 @@ func makeNamePredicate(name string) string {
 	}
 	return fmt.Sprintf(`name == %q`, name)
 }
+
+// combinePredicates joins the non-empty predicate expressions with a logical
+// AND, parenthesizing each so operator precedence inside the individual
+// expressions is preserved. Returns empty if all expressions are empty.
+func combinePredicates(exprs ...string) string {
+	var parts []string
+	for _, expr := range exprs {
+		if expr = strings.TrimSpace(expr); expr != "" {
+			parts = append(parts, "("+expr+")")
+		}
+	}
+	if len(parts) == 1 {
+		// Skip the parentheses when there is nothing to combine.
+		return strings.TrimPrefix(strings.TrimSuffix(parts[0], ")"), "(")
+	}
+	return strings.Join(parts, " && ")
+}
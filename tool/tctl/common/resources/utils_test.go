@@ -127,16 +127,132 @@ func TestFilterByNameOrDiscoveredName(t *testing.T) {
 	}
 }
 
+func TestFilterByNameOrDiscoveredNamePrefix(t *testing.T) {
+	foo1 := mustCreateNewKubeServer(t, "foo-eks-us-west-1", "host-foo", "foo", nil)
+	foo2 := mustCreateNewKubeServer(t, "foo-eks-us-west-2", "host-foo", "foo", nil)
+	fooBar1 := mustCreateNewKubeServer(t, "foo-bar", "host-foo-bar1", "", nil)
+	fooBar2 := mustCreateNewKubeServer(t, "foo-bar-eks-us-west-2", "host-foo-bar2", "foo-bar", nil)
+	resources := []types.KubeServer{
+		foo1, foo2, fooBar1, fooBar2,
+	}
+	hostNameGetter := func(ks types.KubeServer) string { return ks.GetHostname() }
+	tests := []struct {
+		desc           string
+		prefix         string
+		altNameGetters []AltResourceNameFunc[types.KubeServer]
+		want           []types.KubeServer
+	}{
+		{
+			desc:   "matches all names sharing the prefix",
+			prefix: "foo-",
+			want:   []types.KubeServer{foo1, foo2, fooBar1, fooBar2},
+		},
+		{
+			desc:   "narrower prefix matches fewer names",
+			prefix: "foo-bar",
+			want:   []types.KubeServer{fooBar1, fooBar2},
+		},
+		{
+			desc:   "exact full name still matches",
+			prefix: "foo-eks-us-west-1",
+			want:   []types.KubeServer{foo1},
+		},
+		{
+			desc:           "checks alt names for prefix matches",
+			prefix:         "host-foo-bar",
+			altNameGetters: []AltResourceNameFunc[types.KubeServer]{hostNameGetter},
+			want:           []types.KubeServer{fooBar1, fooBar2},
+		},
+		{
+			desc:   "no match",
+			prefix: "bar",
+			want:   nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := FilterByNameOrDiscoveredNamePrefix(resources, test.prefix, test.altNameGetters...)
+			require.Empty(t, cmp.Diff(test.want, got))
+		})
+	}
+}
+
+func TestFilterByLabelSelector(t *testing.T) {
+	prod1 := mustCreateNewKubeServer(t, "prod1", "host-prod1", "", map[string]string{"env": "prod"})
+	prod2 := mustCreateNewKubeServer(t, "prod2", "host-prod2", "", map[string]string{"env": "prod", "region": "us-west-1"})
+	staging := mustCreateNewKubeServer(t, "staging", "host-staging", "", map[string]string{"env": "staging"})
+	resources := []types.KubeServer{prod1, prod2, staging}
+
+	tests := []struct {
+		desc            string
+		selector        string
+		want            []types.KubeServer
+		wantErrContains string
+	}{
+		{
+			desc:     "matches by key=value",
+			selector: "env=prod",
+			want:     []types.KubeServer{prod1, prod2},
+		},
+		{
+			desc:     "matches by key!=value",
+			selector: "env!=prod",
+			want:     []types.KubeServer{staging},
+		},
+		{
+			desc:     "all predicates must hold",
+			selector: "env=prod,region=us-west-1",
+			want:     []types.KubeServer{prod2},
+		},
+		{
+			desc:     "negated predicate matches resources missing the key",
+			selector: "region!=us-east-1",
+			want:     []types.KubeServer{prod1, prod2, staging},
+		},
+		{
+			desc:            "bare terms are rejected",
+			selector:        "env",
+			wantErrContains: "unsupported label predicate",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := FilterByLabelSelector(resources, test.selector)
+			if test.wantErrContains != "" {
+				require.ErrorContains(t, err, test.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Empty(t, cmp.Diff(test.want, got))
+		})
+	}
+}
+
 func TestFormatAmbiguousDeleteMessage(t *testing.T) {
 	ref := services.Ref{Kind: types.KindDatabase, Name: "x"}
 	resDesc := "database"
 	names := []string{"xbbb", "xaaa", "xccc", "xb"}
-	got := formatAmbiguousDeleteMessage(ref, resDesc, names)
+	got := formatAmbiguousDeleteMessage(ref, resDesc, names, nil)
 	require.Contains(t, got, "db/x matches multiple auto-discovered databases",
 		"should have formatted the ref used and pluralized the resource description")
 	wantSortedNames := strings.Join([]string{"xaaa", "xb", "xbbb", "xccc"}, "\n")
 	require.Contains(t, got, wantSortedNames, "should have sorted the matching names")
 	require.Contains(t, got, "$ tctl rm db/xaaa", "should have contained an example command")
+
+	got = formatAmbiguousDeleteMessage(ref, resDesc, names, map[string]string{"xbbb": "x"})
+	require.Contains(t, got, `xbbb (discovered as "x")`,
+		"should have annotated names that differ from their discovered name")
+	require.Contains(t, got, "$ tctl rm db/xaaa",
+		"example command should use the plain resource name")
+}
+
+func TestGetOneResourceNameToDeleteDiscoveredNames(t *testing.T) {
+	foo1 := mustCreateNewKubeServer(t, "foo-eks-us-west-1", "host-foo", "foo", nil)
+	foo2 := mustCreateNewKubeServer(t, "foo-eks-us-west-2", "host-foo", "foo", nil)
+	ref := services.Ref{Kind: types.KindKubeServer, Name: "foo"}
+	_, err := GetOneResourceNameToDelete([]types.KubeServer{foo1, foo2}, ref, "kube server")
+	require.ErrorContains(t, err, `foo-eks-us-west-1 (discovered as "foo")`)
+	require.ErrorContains(t, err, `foo-eks-us-west-2 (discovered as "foo")`)
 }
 
 func makeTestLabels(extraStaticLabels map[string]string) map[string]string {
@@ -188,3 +304,32 @@ var (
 		"label3": "val3",
 	}
 )
+
+func TestCombinePredicates(t *testing.T) {
+	tests := []struct {
+		desc  string
+		exprs []string
+		want  string
+	}{
+		{
+			desc:  "all empty",
+			exprs: []string{"", "  "},
+			want:  "",
+		},
+		{
+			desc:  "single expression unwrapped",
+			exprs: []string{`name == "foo"`, ""},
+			want:  `name == "foo"`,
+		},
+		{
+			desc:  "combined with parentheses",
+			exprs: []string{`name == "foo"`, `labels["env"] == "prod" || labels["env"] == "dev"`},
+			want:  `(name == "foo") && (labels["env"] == "prod" || labels["env"] == "dev")`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			require.Equal(t, test.want, combinePredicates(test.exprs...))
+		})
+	}
+}
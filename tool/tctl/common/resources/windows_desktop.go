@@ -45,6 +45,7 @@ func windowsDesktopHandler() Handler {
 	return Handler{
 		getHandler:    getDesktops,
 		createHandler: createWindowsDesktop,
+		updateHandler: updateWindowsDesktop,
 		deleteHandler: deleteWindowsDesktop,
 		singleton:     false,
 		mfaRequired:   false,
@@ -63,12 +64,18 @@ func (c *windowsDesktopCollection) WriteText(w io.Writer, verbose bool) error {
 	var rows [][]string
 	for _, d := range c.desktops {
 		labels := common.FormatLabels(d.GetAllLabels(), verbose)
-		rows = append(rows, []string{d.GetName(), d.GetAddr(), d.GetDomain(), d.GetHostID(), labels})
+		row := []string{d.GetName(), d.GetAddr(), d.GetDomain(), d.GetHostID(), labels}
+		if verbose {
+			// The origin tells statically configured desktops apart from
+			// dynamically discovered ones; it is empty for static desktops.
+			row = append(row, d.Origin())
+		}
+		rows = append(rows, row)
 	}
 	headers := []string{"Name", "Address", "AD Domain", "Host ID", "Labels"}
 	var t asciitable.Table
 	if verbose {
-		t = asciitable.MakeTable(headers, rows...)
+		t = asciitable.MakeTable(append(headers, "Origin"), rows...)
 	} else {
 		t = asciitable.MakeTableWithTruncatedColumn(headers, rows, "Labels")
 	}
@@ -99,6 +106,12 @@ func getDesktops(ctx context.Context, client *authclient.Client, ref services.Re
 	if err != nil {
 		return nil, trace.Wrap(err)
 	}
+	if opts.Labels != "" {
+		desktops, err = FilterByLabelSelector(desktops, opts.Labels)
+		if err != nil {
+			return nil, trace.Wrap(err)
+		}
+	}
 
 	return &windowsDesktopCollection{desktops: desktops}, nil
 }
@@ -160,6 +173,19 @@ func createWindowsDesktop(ctx context.Context, client *authclient.Client, raw se
 	return nil
 }
 
+func updateWindowsDesktop(ctx context.Context, client *authclient.Client, raw services.UnknownResource, opts CreateOpts) error {
+	wd, err := services.UnmarshalWindowsDesktop(raw.Raw, services.DisallowUnknown())
+	if err != nil {
+		return trace.Wrap(err)
+	}
+
+	if err := client.UpdateWindowsDesktop(ctx, wd); err != nil {
+		return trace.Wrap(err)
+	}
+	fmt.Printf("windows desktop %q has been updated\n", wd.GetName())
+	return nil
+}
+
 type windowsDesktopServiceCollection struct {
 	services []types.WindowsDesktopService
 }
@@ -0,0 +1,63 @@
+// Teleport
+// Copyright (C) 2026 Gravitational, Inc.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gravitational/teleport/api/types"
+	"github.com/gravitational/teleport/lib/asciitable"
+	"github.com/gravitational/teleport/tool/common"
+)
+
+func TestWindowsDesktopCollection_writeText(t *testing.T) {
+	staticDesktop, err := types.NewWindowsDesktopV3("static-desktop",
+		map[string]string{"env": "prod"},
+		types.WindowsDesktopSpecV3{
+			Addr:   "10.0.0.1:3389",
+			Domain: "example.com",
+			HostID: "host-1",
+		})
+	require.NoError(t, err)
+	dynamicDesktop, err := types.NewWindowsDesktopV3("discovered-desktop",
+		map[string]string{types.OriginLabel: types.OriginDynamic},
+		types.WindowsDesktopSpecV3{
+			Addr:   "10.0.0.2:3389",
+			Domain: "example.com",
+			HostID: "host-2",
+		})
+	require.NoError(t, err)
+	collection := &windowsDesktopCollection{desktops: []types.WindowsDesktop{staticDesktop, dynamicDesktop}}
+
+	headers := []string{"Name", "Address", "AD Domain", "Host ID", "Labels"}
+	verboseTable := asciitable.MakeTable(append(headers, "Origin"),
+		[]string{"static-desktop", "10.0.0.1:3389", "example.com", "host-1", "env=prod", ""},
+		[]string{"discovered-desktop", "10.0.0.2:3389", "example.com", "host-2",
+			common.FormatLabels(dynamicDesktop.GetAllLabels(), true), types.OriginDynamic},
+	)
+	nonVerboseTable := asciitable.MakeTableWithTruncatedColumn(headers, [][]string{
+		{"static-desktop", "10.0.0.1:3389", "example.com", "host-1", "env=prod"},
+		{"discovered-desktop", "10.0.0.2:3389", "example.com", "host-2",
+			common.FormatLabels(dynamicDesktop.GetAllLabels(), false)},
+	}, "Labels")
+
+	collectionFormatTest(t, collection,
+		verboseTable.AsBuffer().String(),
+		nonVerboseTable.AsBuffer().String())
+}